@@ -0,0 +1,100 @@
+package gostat
+
+import (
+	"math"
+)
+
+// MAE returns the mean absolute error of forecast values against actual
+// values.
+func MAE(forecast, actual []float64) float64 {
+	return meanError(forecast, actual, func(f, a float64) float64 {
+		return math.Abs(f - a)
+	})
+}
+
+// RMSE returns the root mean squared error of forecast values against
+// actual values.
+func RMSE(forecast, actual []float64) float64 {
+	return math.Sqrt(meanError(forecast, actual, func(f, a float64) float64 {
+		return (f - a) * (f - a)
+	}))
+}
+
+// MAPE returns the mean absolute percentage error of forecast values against
+// actual values as a fraction, so 0.05 means 5%. It is infinite when any
+// actual value is zero.
+func MAPE(forecast, actual []float64) float64 {
+	return meanError(forecast, actual, func(f, a float64) float64 {
+		return math.Abs((f - a) / a)
+	})
+}
+
+// SMAPE returns the symmetric mean absolute percentage error of forecast
+// values against actual values as a fraction between 0 and 2, where each
+// error is divided by the average magnitude of the forecast and the actual
+// value. A pair of zero values contributes no error.
+func SMAPE(forecast, actual []float64) float64 {
+	return meanError(forecast, actual, func(f, a float64) float64 {
+		if f == 0 && a == 0 {
+			return 0
+		}
+		return 2 * math.Abs(f-a) / (math.Abs(f) + math.Abs(a))
+	})
+}
+
+// MASE returns the mean absolute scaled error of forecast values against
+// actual values, the MAE of the forecast divided by the in-sample MAE of the
+// seasonal naive forecast, which predicts each value of insample by the
+// value m periods earlier. Use m = 1 for non-seasonal data. A MASE below 1
+// means the forecast beats the naive one.
+func MASE(forecast, actual, insample []float64, m int) float64 {
+	if m < 1 || len(insample) <= m {
+		return math.NaN()
+	}
+	naive := MAE(insample[m:], insample[:len(insample)-m])
+	return MAE(forecast, actual) / naive
+}
+
+// TheilU returns Theil's U statistic of forecast values against actual
+// values, the ratio of the root mean squared relative error of the forecast
+// to that of the naive forecast predicting no change from the previous
+// actual value. A U below 1 means the forecast beats the naive one.
+func TheilU(forecast, actual []float64) float64 {
+	checkSeries([][]float64{forecast, actual})
+	var num, den float64
+	for i := 1; i < len(actual); i++ {
+		e := (forecast[i] - actual[i]) / actual[i-1]
+		d := (actual[i] - actual[i-1]) / actual[i-1]
+		num += e * e
+		den += d * d
+	}
+	if len(actual) < 2 {
+		return math.NaN()
+	}
+	return math.Sqrt(num / den)
+}
+
+// PinballLoss returns the mean pinball loss of forecast values of the
+// p-quantile against actual values, which weights actual values above the
+// forecast by p and those below it by 1-p, so that it is minimized by the
+// true p-quantile. With p of 0.5 it is half the MAE.
+func PinballLoss(forecast, actual []float64, p float64) float64 {
+	return meanError(forecast, actual, func(f, a float64) float64 {
+		if a >= f {
+			return p * (a - f)
+		}
+		return (1 - p) * (f - a)
+	})
+}
+
+func meanError(forecast, actual []float64, loss func(f, a float64) float64) float64 {
+	checkSeries([][]float64{forecast, actual})
+	if len(actual) == 0 {
+		return math.NaN()
+	}
+	var sum neumaierSum
+	for i := 0; i < len(actual); i++ {
+		sum.add(loss(forecast[i], actual[i]))
+	}
+	return sum.total() / float64(len(actual))
+}
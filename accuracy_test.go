@@ -0,0 +1,41 @@
+package gostat
+
+import (
+	"math"
+	"testing"
+)
+
+func TestForecastAccuracy(t *testing.T) {
+	forecast := []float64{11., 19., 32., 38.}
+	actual := []float64{10., 20., 30., 40.}
+	cases := []struct {
+		name string
+		got  float64
+		want float64
+	}{
+		{"MAE", MAE(forecast, actual), 1.5},
+		{"RMSE", RMSE(forecast, actual), 1.5811},
+		{"MAPE", MAPE(forecast, actual), 0.0667},
+		{"SMAPE", SMAPE(forecast, actual), 0.0656},
+		{"MASE", MASE(forecast, actual, []float64{1., 3., 2., 5., 4.}, 1), 0.8571},
+		{"TheilU", TheilU(forecast, actual), 0.1340},
+		{"PinballLoss", PinballLoss(forecast, actual, 0.9), 0.75},
+	}
+	for _, c := range cases {
+		if !floatEquals(c.got, c.want) {
+			t.Errorf("Expected %s=%f, got=%f", c.name, c.want, c.got)
+		}
+	}
+}
+
+func TestForecastAccuracy_Empty(t *testing.T) {
+	if got := MAE(nil, nil); !math.IsNaN(got) {
+		t.Errorf("Expected MAE=NaN, got=%f", got)
+	}
+	if got := MASE([]float64{1.}, []float64{1.}, []float64{1.}, 1); !math.IsNaN(got) {
+		t.Errorf("Expected MASE=NaN, got=%f", got)
+	}
+	if got := SMAPE([]float64{0.}, []float64{0.}); got != 0 {
+		t.Errorf("Expected SMAPE=0, got=%f", got)
+	}
+}
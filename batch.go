@@ -0,0 +1,76 @@
+package gostat
+
+import (
+	"math"
+	"runtime"
+	"sort"
+	"sync"
+)
+
+// Batch returns the statistic calculated by fn for each of many series,
+// such as the series of every entity in a large universe, so that the i-th
+// value is fn(series[i]). The series are spread over one goroutine per
+// processor, so fn must be safe for concurrent use and must not modify the
+// series.
+func Batch(series [][]float64, fn func(x []float64) float64) []float64 {
+	rets := make([]float64, len(series))
+	batchEach(len(series), func(i int, buf []float64) []float64 {
+		rets[i] = fn(series[i])
+		return buf
+	})
+	return rets
+}
+
+// MedianBatch returns the Median of each series, NaN for an empty series.
+// Each goroutine sorts the series in a single buffer, so that no memory is
+// allocated per series.
+func MedianBatch(series [][]float64) []float64 {
+	rets := make([]float64, len(series))
+	batchEach(len(series), func(i int, buf []float64) []float64 {
+		if len(series[i]) == 0 {
+			rets[i] = math.NaN()
+			return buf
+		}
+		buf = append(buf[:0], series[i]...)
+		sort.Float64s(buf)
+		rets[i] = medianSorted(buf)
+		return buf
+	})
+	return rets
+}
+
+// VolatilityBatch returns the Volatility of each series of prices, with the
+// logarithmic returns of each series calculated in a buffer shared by the
+// series of a goroutine.
+func VolatilityBatch(series [][]float64, periodicity float64) []float64 {
+	rets := make([]float64, len(series))
+	batchEach(len(series), func(i int, buf []float64) []float64 {
+		prices := series[i]
+		buf = buf[:0]
+		for j := 1; j < len(prices); j++ {
+			buf = append(buf, math.Log(prices[j]/prices[j-1]))
+		}
+		rets[i] = StdDev(buf, nil, VarianceTwoPass) * math.Sqrt(periodicity)
+		return buf
+	})
+	return rets
+}
+
+// batchEach calls fn for each of n series, split into contiguous chunks
+// over one goroutine per processor. Each goroutine passes fn a buffer,
+// which fn may use and returns to be passed for the next series.
+func batchEach(n int, fn func(i int, buf []float64) []float64) {
+	workers := minInt(runtime.GOMAXPROCS(0), n)
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func(start, end int) {
+			defer wg.Done()
+			var buf []float64
+			for i := start; i < end; i++ {
+				buf = fn(i, buf)
+			}
+		}(w*n/workers, (w+1)*n/workers)
+	}
+	wg.Wait()
+}
@@ -0,0 +1,40 @@
+package gostat
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func TestBatch(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	series := make([][]float64, 100)
+	for i := 0; i < len(series); i++ {
+		series[i] = make([]float64, 2+rng.Intn(20))
+		series[i][0] = 100
+		for j := 1; j < len(series[i]); j++ {
+			series[i][j] = series[i][j-1] * math.Exp(0.01*rng.NormFloat64())
+		}
+	}
+	medians := MedianBatch(series)
+	vols := VolatilityBatch(series, 252)
+	sums := Batch(series, Sum)
+	for i := 0; i < len(series); i++ {
+		if got, want := medians[i], Median(series[i]); got != want {
+			t.Errorf("Expected median[%d]=%f, got=%f", i, want, got)
+		}
+		if got, want := vols[i], Volatility(series[i], 252); !floatEquals(got, want) {
+			t.Errorf("Expected volatility[%d]=%f, got=%f", i, want, got)
+		}
+		if got, want := sums[i], Sum(series[i]); got != want {
+			t.Errorf("Expected sum[%d]=%f, got=%f", i, want, got)
+		}
+	}
+}
+
+func TestMedianBatch_Empty(t *testing.T) {
+	compareArrays([]float64{math.NaN(), 2., 2.5}, MedianBatch([][]float64{nil, {3., 1., 2.}, {4., 1.}}), t)
+	if got := MedianBatch(nil); len(got) != 0 {
+		t.Errorf("Expected no medians, got=%v", got)
+	}
+}
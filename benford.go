@@ -0,0 +1,115 @@
+package gostat
+
+import (
+	"github.com/gonum/stat/distuv"
+	"math"
+	"strconv"
+)
+
+// BenfordConformity is the conformity of a sample to Benford's law by the
+// mean absolute deviation of its digit proportions, following the ranges
+// of Nigrini.
+type BenfordConformity int
+
+const (
+	// BenfordClose is close conformity.
+	BenfordClose BenfordConformity = iota
+	// BenfordAcceptable is acceptable conformity.
+	BenfordAcceptable
+	// BenfordMarginal is marginally acceptable conformity.
+	BenfordMarginal
+	// BenfordNonconformity is nonconformity.
+	BenfordNonconformity
+)
+
+// BenfordTest is the result of Benford.
+type BenfordTest struct {
+	// Digits are the leading digits tested, 1 to 9 for the first digit or
+	// 10 to 99 for the first two digits.
+	Digits []int
+	// Observed and Expected are the observed proportions of each leading
+	// digit and the proportions expected by Benford's law.
+	Observed, Expected []float64
+	// Count is the number of values tested.
+	Count int
+	// ChiSquare is the chi-square statistic of the observed counts and
+	// PValue its p-value.
+	ChiSquare, PValue float64
+	// MAD is the mean absolute deviation of the observed proportions from
+	// the expected ones, and Conformity its classification.
+	MAD        float64
+	Conformity BenfordConformity
+}
+
+// Benford tests the leading digits of x against Benford's law, the
+// distribution of first digits in many naturally occurring data sets such
+// as transaction amounts, with digits 1 for the first digit test or 2 for
+// the first two digits test. Zero, NaN and infinite values are ignored, and
+// the sign is ignored. ErrInvalidParameter is returned for other digits,
+// and ErrEmptyInput when no value can be tested.
+func Benford(x []float64, digits int) (BenfordTest, error) {
+	if digits != 1 && digits != 2 {
+		return BenfordTest{}, ErrInvalidParameter
+	}
+	lo, hi := 1, 9
+	limits := []float64{0.006, 0.012, 0.015}
+	if digits == 2 {
+		lo, hi = 10, 99
+		limits = []float64{0.0012, 0.0018, 0.0022}
+	}
+	counts := make([]float64, hi-lo+1)
+	var n int
+	for i := 0; i < len(x); i++ {
+		if x[i] == 0 || !isRealVal(x[i]) {
+			continue
+		}
+		counts[leadingDigits(x[i], digits)-lo]++
+		n++
+	}
+	if n == 0 {
+		return BenfordTest{}, ErrEmptyInput
+	}
+
+	res := BenfordTest{
+		Digits:   make([]int, len(counts)),
+		Observed: make([]float64, len(counts)),
+		Expected: make([]float64, len(counts)),
+		Count:    n,
+	}
+	var mad float64
+	for i := 0; i < len(counts); i++ {
+		d := lo + i
+		p := math.Log10(1 + 1/float64(d))
+		res.Digits[i] = d
+		res.Observed[i] = counts[i] / float64(n)
+		res.Expected[i] = p
+		e := p * float64(n)
+		res.ChiSquare += (counts[i] - e) * (counts[i] - e) / e
+		mad += math.Abs(res.Observed[i] - p)
+	}
+	res.MAD = mad / float64(len(counts))
+	res.PValue = 1 - distuv.ChiSquared{K: float64(len(counts) - 1)}.CDF(res.ChiSquare)
+	res.Conformity = BenfordNonconformity
+	for i := 0; i < len(limits); i++ {
+		if res.MAD <= limits[i] {
+			res.Conformity = BenfordConformity(i)
+			break
+		}
+	}
+	return res, nil
+}
+
+// leadingDigits returns the first digits of a non-zero finite value,
+// reading them from its decimal representation to avoid the rounding
+// errors of scaling by powers of ten.
+func leadingDigits(v float64, digits int) int {
+	s := strconv.FormatFloat(math.Abs(v), 'e', -1, 64)
+	d := int(s[0] - '0')
+	if digits == 2 {
+		d *= 10
+		if s[1] == '.' {
+			d += int(s[2] - '0')
+		}
+	}
+	return d
+}
@@ -0,0 +1,75 @@
+package gostat
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func TestBenford(t *testing.T) {
+	// values uniformly distributed on a log scale over whole decades follow
+	// Benford's law
+	rng := rand.New(rand.NewSource(1))
+	x := make([]float64, 20000)
+	for i := 0; i < len(x); i++ {
+		x[i] = math.Pow(10, 6*rng.Float64())
+	}
+	res, err := Benford(x, 1)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if got, want := res.Expected[0], 0.30103; !floatEquals(got, want) {
+		t.Errorf("Expected proportion of 1=%f, got=%f", want, got)
+	}
+	if res.Conformity != BenfordClose || res.PValue < 0.001 {
+		t.Errorf("Expected close conformity, got MAD=%f and p-value=%f", res.MAD, res.PValue)
+	}
+	res, err = Benford(x, 2)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if got, want := len(res.Digits), 90; got != want {
+		t.Errorf("Expected number of digits=%d, got=%d", want, got)
+	}
+	if res.Conformity == BenfordNonconformity {
+		t.Errorf("Expected conformity, got MAD=%f", res.MAD)
+	}
+}
+
+func TestBenford_Uniform(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	x := make([]float64, 5000)
+	for i := 0; i < len(x); i++ {
+		x[i] = 100 + 900*rng.Float64()
+	}
+	res, err := Benford(x, 1)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if res.Conformity != BenfordNonconformity || res.PValue > 0.001 {
+		t.Errorf("Expected nonconformity, got MAD=%f and p-value=%f", res.MAD, res.PValue)
+	}
+}
+
+func TestLeadingDigits(t *testing.T) {
+	cases := []struct {
+		v         float64
+		digits, d int
+	}{
+		{0.3, 1, 3}, {0.3, 2, 30}, {1.96, 1, 1}, {1.96, 2, 19}, {-4567., 2, 45}, {1000., 1, 1},
+	}
+	for _, c := range cases {
+		if got := leadingDigits(c.v, c.digits); got != c.d {
+			t.Errorf("Expected leading digits of %f=%d, got=%d", c.v, c.d, got)
+		}
+	}
+}
+
+func TestBenford_Errors(t *testing.T) {
+	if _, err := Benford([]float64{1.}, 3); err != ErrInvalidParameter {
+		t.Errorf("Expected error=%v, got=%v", ErrInvalidParameter, err)
+	}
+	if _, err := Benford([]float64{0., math.NaN()}, 1); err != ErrEmptyInput {
+		t.Errorf("Expected error=%v, got=%v", ErrEmptyInput, err)
+	}
+}
@@ -0,0 +1,98 @@
+package gostat
+
+import (
+	"math"
+	"sort"
+	"strconv"
+)
+
+// Binning is the result of Cut and QCut.
+type Binning struct {
+	// Bins holds the index of the bin of each value, -1 for NaN values and
+	// values outside of the edges.
+	Bins []int
+	// Edges are the ascending bounds of the bins, one more than there are
+	// bins.
+	Edges []float64
+	// Labels are the intervals covered by the bins, such as "(1, 2.5]".
+	Labels []string
+}
+
+// Cut discretizes x into the bins bounded by ascending edges, such as for
+// the features of a downstream model. Following the usual convention the
+// bins are closed on the right, (edges[k], edges[k+1]], except the first one
+// which also holds the lowest edge. ErrInvalidParameter is returned when
+// there are fewer than two edges or they are not strictly ascending.
+func Cut(x, edges []float64) (Binning, error) {
+	if len(edges) < 2 {
+		return Binning{}, ErrInvalidParameter
+	}
+	for k := 0; k < len(edges); k++ {
+		if !isRealVal(edges[k]) || (k > 0 && edges[k] <= edges[k-1]) {
+			return Binning{}, ErrInvalidParameter
+		}
+	}
+	return cut(x, append([]float64{}, edges...)), nil
+}
+
+// QCut discretizes x like Cut into q bins of equal frequency, bounded by the
+// q-quantiles of its finite values interpolated linearly, quartiles for 4
+// bins, so that each holds about the same number of values. Bounds shared by
+// tied values are merged, leaving fewer bins, a single one when all the
+// values are equal. ErrInvalidParameter is returned when q is smaller than
+// one, and ErrEmptyInput when x has no finite values.
+func QCut(x []float64, q int) (Binning, error) {
+	if q < 1 {
+		return Binning{}, ErrInvalidParameter
+	}
+	sorted := sortedFinite(x)
+	if len(sorted) == 0 {
+		return Binning{}, ErrEmptyInput
+	}
+	edges := []float64{sorted[0]}
+	for k := 1; k <= q; k++ {
+		e := quantileSorted(sorted, float64(k)/float64(q), QuantileLinear)
+		if e > edges[len(edges)-1] {
+			edges = append(edges, e)
+		}
+	}
+	if len(edges) == 1 {
+		// all the values are equal and fall into a single bin
+		edges = append(edges, edges[0])
+	}
+	return cut(x, edges), nil
+}
+
+// cut discretizes x into the bins bounded by edges, as described by Cut.
+func cut(x, edges []float64) Binning {
+	b := Binning{
+		Bins:   make([]int, len(x)),
+		Edges:  edges,
+		Labels: make([]string, len(edges)-1),
+	}
+	for i := 0; i < len(x); i++ {
+		b.Bins[i] = cutBin(x[i], edges)
+	}
+	for k := 0; k < len(b.Labels); k++ {
+		open := "("
+		if k == 0 {
+			open = "["
+		}
+		b.Labels[k] = open + formatEdge(edges[k]) + ", " + formatEdge(edges[k+1]) + "]"
+	}
+	return b
+}
+
+// cutBin returns the index of the bin of v bounded by edges, as described
+// by Cut, or -1.
+func cutBin(v float64, edges []float64) int {
+	if math.IsNaN(v) || v < edges[0] || v > edges[len(edges)-1] {
+		return -1
+	}
+	k := sort.Search(len(edges), func(k int) bool { return edges[k] >= v })
+	return maxInt(k-1, 0)
+}
+
+func formatEdge(v float64) string {
+	return strconv.FormatFloat(v, 'g', -1, 64)
+}
@@ -0,0 +1,66 @@
+package gostat
+
+import (
+	"math"
+	"testing"
+)
+
+func TestCut(t *testing.T) {
+	x := []float64{0., 0.5, 1., 1.5, 3., 4., -1., math.NaN()}
+	b, err := Cut(x, []float64{0., 1., 2., 3.})
+	if err != nil {
+		t.Fatalf("Expected no error, got=%v", err)
+	}
+	compareIndices([]int{0, 0, 0, 1, 2, -1, -1, -1}, b.Bins, t)
+	want := []string{"[0, 1]", "(1, 2]", "(2, 3]"}
+	for k := 0; k < len(want); k++ {
+		if b.Labels[k] != want[k] {
+			t.Errorf("Expected label[%d]=%s, got=%s", k, want[k], b.Labels[k])
+		}
+	}
+	for _, edges := range [][]float64{nil, {1.}, {1., 1.}, {2., 1.}, {0., math.NaN()}} {
+		if _, err := Cut(x, edges); err != ErrInvalidParameter {
+			t.Errorf("Expected err=%v for edges=%v, got=%v", ErrInvalidParameter, edges, err)
+		}
+	}
+}
+
+func TestQCut(t *testing.T) {
+	x := []float64{8., 1., 7., 2., 6., 3., 5., 4., math.NaN()}
+	b, err := QCut(x, 4)
+	if err != nil {
+		t.Fatalf("Expected no error, got=%v", err)
+	}
+	compareArrays([]float64{1., 2.75, 4.5, 6.25, 8.}, b.Edges, t)
+	compareIndices([]int{3, 0, 3, 0, 2, 1, 2, 1, -1}, b.Bins, t)
+	if got, want := b.Labels[1], "(2.75, 4.5]"; got != want {
+		t.Errorf("Expected label=%s, got=%s", want, got)
+	}
+}
+
+func TestQCut_Ties(t *testing.T) {
+	b, err := QCut([]float64{1., 1., 1., 1., 2., 3.}, 4)
+	if err != nil {
+		t.Fatalf("Expected no error, got=%v", err)
+	}
+	compareArrays([]float64{1., 1.75, 3.}, b.Edges, t)
+	compareIndices([]int{0, 0, 0, 0, 1, 1}, b.Bins, t)
+
+	b, err = QCut([]float64{2., 2., 2.}, 3)
+	if err != nil {
+		t.Fatalf("Expected no error, got=%v", err)
+	}
+	compareIndices([]int{0, 0, 0}, b.Bins, t)
+	if got, want := b.Labels, []string{"[2, 2]"}; len(got) != 1 || got[0] != want[0] {
+		t.Errorf("Expected labels=%v, got=%v", want, got)
+	}
+}
+
+func TestQCut_Invalid(t *testing.T) {
+	if _, err := QCut([]float64{1.}, 0); err != ErrInvalidParameter {
+		t.Errorf("Expected err=%v, got=%v", ErrInvalidParameter, err)
+	}
+	if _, err := QCut([]float64{math.NaN()}, 4); err != ErrEmptyInput {
+		t.Errorf("Expected err=%v, got=%v", ErrEmptyInput, err)
+	}
+}
@@ -0,0 +1,157 @@
+package gostat
+
+import (
+	"github.com/gonum/stat/distuv"
+	"math"
+	"math/rand"
+	"sort"
+)
+
+// BootstrapMethod selects how Bootstrap derives a confidence interval from
+// the distribution of the resampled statistics.
+type BootstrapMethod int
+
+const (
+	// BootstrapPercentile uses the quantiles of the resampled statistics.
+	BootstrapPercentile BootstrapMethod = iota
+	// BootstrapBCa uses the bias-corrected and accelerated quantiles of
+	// the resampled statistics, which adjust the percentile interval for
+	// the bias and the skewness of the statistic, with the acceleration
+	// estimated by the jackknife. The acceleration is 0 for fewer than two
+	// observations, or when the statistic is NaN without one of them.
+	BootstrapBCa
+)
+
+// BootstrapOpts controls how Bootstrap resamples a series.
+type BootstrapOpts struct {
+	// Method selects the confidence interval.
+	Method BootstrapMethod
+	// Confidence is the confidence level of the interval, 0.95 when zero.
+	Confidence float64
+	// Source is the source of random numbers used to draw the resamples.
+	// When nil the source set by SetSource is used, by default a source
+	// with a fixed seed so that the results are reproducible.
+	Source rand.Source
+	// BlockSize is the length of the blocks of consecutive observations
+	// drawn by the circular block bootstrap, which preserves the
+	// autocorrelation of a series such as returns for Volatility. Values
+	// below 2 draw independent observations.
+	BlockSize int
+}
+
+// Bootstrap returns the statistic statFn of x with a confidence interval
+// estimated from nResamples resamples of x drawn with replacement. statFn
+// must not retain the slice it receives, which is reused between resamples.
+// Resamples for which statFn returns NaN are ignored. The interval is NaN
+// when nResamples is less than one, and all three values are NaN for an
+// empty slice.
+func Bootstrap(x []float64, statFn func([]float64) float64, nResamples int, opts BootstrapOpts) (estimate, ciLow, ciHigh float64) {
+	if len(x) == 0 {
+		return math.NaN(), math.NaN(), math.NaN()
+	}
+	estimate = statFn(x)
+	if nResamples < 1 {
+		return estimate, math.NaN(), math.NaN()
+	}
+	confidence := opts.Confidence
+	if confidence == 0 {
+		confidence = 0.95
+	}
+	rng := rand.New(sourceOr(opts.Source))
+
+	stats := make([]float64, 0, nResamples)
+	sample := make([]float64, len(x))
+	for r := 0; r < nResamples; r++ {
+		resample(sample, x, opts.BlockSize, rng)
+		if s := statFn(sample); !math.IsNaN(s) {
+			stats = append(stats, s)
+		}
+	}
+	if len(stats) == 0 {
+		return estimate, math.NaN(), math.NaN()
+	}
+	sort.Float64s(stats)
+
+	alpha := (1 - confidence) / 2
+	lo, hi := alpha, 1-alpha
+	if opts.Method == BootstrapBCa {
+		lo, hi = bcaLevels(x, statFn, estimate, stats, alpha)
+	}
+	return estimate, quantileSorted(stats, lo, QuantileLinear), quantileSorted(stats, hi, QuantileLinear)
+}
+
+// MovBootstrap returns a local k-point statistic statFn over the sliding
+// windows of x selected by opts, as by MovApply, with pointwise confidence
+// bands from nResamples bootstrap resamples of each window, such as a
+// rolling Sharpe ratio or a rolling volatility with their uncertainty. Set
+// BlockSize in bopts to draw blocks of consecutive observations, which keeps
+// the autocorrelation of the series within each window. The three slices
+// are aligned with each other, and with x when Pad is set in opts. All
+// windows draw from the same source of random numbers in bopts. Weights in
+// opts are ignored.
+func MovBootstrap(x []float64, k int, opts WindowOpts, statFn func([]float64) float64, nResamples int, bopts BootstrapOpts) (estimate, lower, upper []float64) {
+	bopts.Source = sourceOr(bopts.Source)
+	rets := MovApplyMulti(x, k, opts, 3, func(window, _, dst []float64) {
+		dst[0], dst[1], dst[2] = Bootstrap(window, statFn, nResamples, bopts)
+	})
+	return rets[0], rets[1], rets[2]
+}
+
+// resample fills dst with observations of x drawn with replacement, in
+// circular blocks of length block when it is at least 2.
+func resample(dst, x []float64, block int, rng *rand.Rand) {
+	n := len(x)
+	if block < 2 {
+		for i := 0; i < len(dst); i++ {
+			dst[i] = x[rng.Intn(n)]
+		}
+		return
+	}
+	for i := 0; i < len(dst); {
+		start := rng.Intn(n)
+		for j := 0; j < block && i < len(dst); j++ {
+			dst[i] = x[(start+j)%n]
+			i++
+		}
+	}
+}
+
+// bcaLevels returns the adjusted quantile levels of the BCa interval for
+// the two-sided level alpha.
+func bcaLevels(x []float64, statFn func([]float64) float64, estimate float64, stats []float64, alpha float64) (lo, hi float64) {
+	below := sort.SearchFloat64s(stats, estimate)
+	z0 := distuv.UnitNormal.Quantile(float64(below) / float64(len(stats)))
+
+	// jackknife estimate of the acceleration, left at 0 when there are too
+	// few observations or the statistic is undefined without one of them
+	var a float64
+	if n := len(x); n >= 2 {
+		jack := make([]float64, n)
+		sample := make([]float64, n-1)
+		for i := 0; i < n; i++ {
+			copy(sample, x[:i])
+			copy(sample[i:], x[i+1:])
+			jack[i] = statFn(sample)
+		}
+		mean := Mean(jack, nil)
+		var num, den float64
+		for i := 0; i < n; i++ {
+			d := mean - jack[i]
+			num += d * d * d
+			den += d * d
+		}
+		if den > 0 {
+			a = num / (6 * math.Pow(den, 1.5))
+		}
+	}
+
+	level := func(p float64) float64 {
+		z := distuv.UnitNormal.Quantile(p)
+		l := distuv.UnitNormal.CDF(z0 + (z0+z)/(1-a*(z0+z)))
+		if math.IsNaN(l) {
+			return p
+		}
+		return l
+	}
+	return level(alpha), level(1 - alpha)
+}
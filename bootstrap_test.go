@@ -0,0 +1,127 @@
+package gostat
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func bootstrapSample() []float64 {
+	rng := rand.New(rand.NewSource(7))
+	x := make([]float64, 200)
+	for i := 0; i < len(x); i++ {
+		x[i] = 10 + 2*rng.NormFloat64()
+	}
+	return x
+}
+
+func TestBootstrap_Percentile(t *testing.T) {
+	x := bootstrapSample()
+	mean := func(v []float64) float64 { return Mean(v, nil) }
+	est, lo, hi := Bootstrap(x, mean, 2000, BootstrapOpts{})
+	if got, want := est, Mean(x, nil); !floatEquals(got, want) {
+		t.Errorf("Expected estimate=%f, got=%f", want, got)
+	}
+	// the standard error of the mean is about 2/sqrt(200)
+	se := StdDev(x, nil, VarianceTwoPass) / math.Sqrt(float64(len(x)))
+	if math.Abs((hi-lo)-2*1.96*se) > 0.1*se*2*1.96 {
+		t.Errorf("Expected interval width close to %f, got=%f", 2*1.96*se, hi-lo)
+	}
+	if lo >= est || hi <= est {
+		t.Errorf("Expected interval around estimate=%f, got=[%f, %f]", est, lo, hi)
+	}
+	_, lo2, hi2 := Bootstrap(x, mean, 2000, BootstrapOpts{})
+	if lo != lo2 || hi != hi2 {
+		t.Errorf("Expected reproducible interval [%f, %f], got=[%f, %f]", lo, hi, lo2, hi2)
+	}
+}
+
+func TestBootstrap_BCa(t *testing.T) {
+	x := bootstrapSample()
+	est, lo, hi := Bootstrap(x, Median, 1000, BootstrapOpts{Method: BootstrapBCa, Confidence: 0.9, Source: rand.NewSource(3)})
+	if lo >= est || hi <= est {
+		t.Errorf("Expected interval around estimate=%f, got=[%f, %f]", est, lo, hi)
+	}
+	_, plo, phi := Bootstrap(x, Median, 1000, BootstrapOpts{Confidence: 0.9, Source: rand.NewSource(3)})
+	if math.Abs(lo-plo) > 0.5 || math.Abs(hi-phi) > 0.5 {
+		t.Errorf("Expected BCa interval close to [%f, %f], got=[%f, %f]", plo, phi, lo, hi)
+	}
+}
+
+func TestBootstrap_BCaSmall(t *testing.T) {
+	opts := BootstrapOpts{Method: BootstrapBCa}
+	est, lo, hi := Bootstrap([]float64{3.}, Median, 50, opts)
+	if est != 3 || lo != 3 || hi != 3 {
+		t.Errorf("Expected interval=[3, 3] around 3, got=[%f, %f] around %f", lo, hi, est)
+	}
+	est, lo, hi = Bootstrap([]float64{1., 3.}, Median, 200, opts)
+	if est != 2 || !(lo >= 1 && lo <= est && hi >= est && hi <= 3) {
+		t.Errorf("Expected interval within [1, 3] around 2, got=[%f, %f] around %f", lo, hi, est)
+	}
+	// the jackknife standard deviation of a single value is NaN
+	sd := func(v []float64) float64 { return StdDev(v, nil, VarianceTwoPass) }
+	_, lo, hi = Bootstrap([]float64{1., 3.}, sd, 200, opts)
+	if math.IsNaN(lo) || math.IsNaN(hi) || lo > hi {
+		t.Errorf("Expected an interval, got=[%f, %f]", lo, hi)
+	}
+	_, mlo, _ := MovBootstrap([]float64{1., 2., 3.}, 2, WindowOpts{Trailing: true}, Median, 50, opts)
+	if got, want := mlo[0], 1.; got != want {
+		t.Errorf("Expected lower bound of the first window=%f, got=%f", want, got)
+	}
+}
+
+func TestBootstrap_Block(t *testing.T) {
+	x := bootstrapSample()
+	sample := make([]float64, 10)
+	resample(sample, x, 4, rand.New(rand.NewSource(1)))
+	var runs int
+	for i := 1; i < len(sample); i++ {
+		for j := 0; j < len(x); j++ {
+			if x[j] == sample[i-1] && x[(j+1)%len(x)] == sample[i] {
+				runs++
+				break
+			}
+		}
+	}
+	if runs < 6 {
+		t.Errorf("Expected blocks of consecutive observations, got=%v", sample)
+	}
+	_, lo, hi := Bootstrap(x, func(v []float64) float64 { return StdDev(v, nil, VarianceTwoPass) }, 500, BootstrapOpts{BlockSize: 5})
+	if !(lo < hi) {
+		t.Errorf("Expected non-empty interval, got=[%f, %f]", lo, hi)
+	}
+}
+
+func TestBootstrap_Empty(t *testing.T) {
+	est, lo, hi := Bootstrap(nil, Median, 100, BootstrapOpts{})
+	if !math.IsNaN(est) || !math.IsNaN(lo) || !math.IsNaN(hi) {
+		t.Errorf("Expected NaN, got=%f [%f, %f]", est, lo, hi)
+	}
+	est, lo, _ = Bootstrap([]float64{1., 2., 3.}, Median, 0, BootstrapOpts{})
+	if est != 2. || !math.IsNaN(lo) {
+		t.Errorf("Expected estimate=2 and NaN interval, got=%f [%f]", est, lo)
+	}
+}
+
+func TestMovBootstrap(t *testing.T) {
+	x := bootstrapSample()
+	mean := func(v []float64) float64 { return Mean(v, nil) }
+	opts := WindowOpts{Trailing: true, FullWindow: true, Pad: true}
+	est, lo, hi := MovBootstrap(x, 50, opts, mean, 500, BootstrapOpts{BlockSize: 5})
+	if len(est) != len(x) || len(lo) != len(x) || len(hi) != len(x) {
+		t.Fatalf("Expected %d values, got=%d, %d, %d", len(x), len(est), len(lo), len(hi))
+	}
+	compareArrays(MovMean(x, 50, opts), est, t)
+	for i := 0; i < len(x); i++ {
+		if i < 49 {
+			if !math.IsNaN(lo[i]) || !math.IsNaN(hi[i]) {
+				t.Errorf("Expected no band at %d, got=[%f, %f]", i, lo[i], hi[i])
+			}
+			continue
+		}
+		// the standard error of the mean of 50 values is about 2/sqrt(50)
+		if lo[i] >= est[i] || hi[i] <= est[i] || hi[i]-lo[i] > 2 {
+			t.Errorf("Expected band around estimate=%f at %d, got=[%f, %f]", est[i], i, lo[i], hi[i])
+		}
+	}
+}
@@ -0,0 +1,89 @@
+package gostat
+
+// Derivative returns the rate of change of x with respect to the times t,
+// or to the observation index when t is nil, at each observation. The times
+// must be strictly increasing but need not be evenly spaced.
+//
+// With k below 3 the derivative is estimated by finite differences, central
+// differences weighted for the spacing of the neighboring times inside the
+// series and one-sided differences at its ends. With k of 3 or more it is
+// the slope of the least squares line over the k observations centered on
+// each one, shifted inwards at the ends of the series, which smooths out
+// noise at the cost of some bias where the slope changes quickly.
+func Derivative(t, x []float64, k int) ([]float64, error) {
+	t, err := checkTimes(t, x)
+	if err != nil {
+		return nil, err
+	}
+	n := len(x)
+	rets := make([]float64, n)
+	if k < 3 {
+		rets[0] = (x[1] - x[0]) / (t[1] - t[0])
+		rets[n-1] = (x[n-1] - x[n-2]) / (t[n-1] - t[n-2])
+		for i := 1; i < n-1; i++ {
+			h0, h1 := t[i]-t[i-1], t[i+1]-t[i]
+			rets[i] = (h0*h0*x[i+1] - h1*h1*x[i-1] + (h1*h1-h0*h0)*x[i]) / (h0 * h1 * (h0 + h1))
+		}
+		return rets, nil
+	}
+	k = minInt(k, n)
+	for i := 0; i < n; i++ {
+		start := maxInt(i-k/2, 0)
+		end := minInt(start+k, n)
+		start = end - k
+		var mt, mx float64
+		for j := start; j < end; j++ {
+			mt += t[j]
+			mx += x[j]
+		}
+		mt /= float64(k)
+		mx /= float64(k)
+		var stt, stx float64
+		for j := start; j < end; j++ {
+			stt += (t[j] - mt) * (t[j] - mt)
+			stx += (t[j] - mt) * (x[j] - mx)
+		}
+		rets[i] = stx / stt
+	}
+	return rets, nil
+}
+
+// Integrate returns the integral of x over the times t, or over the
+// observation index when t is nil, by the trapezoidal rule, such as the
+// area under a curve sampled at irregular times. The times must be strictly
+// increasing.
+func Integrate(t, x []float64) (float64, error) {
+	t, err := checkTimes(t, x)
+	if err != nil {
+		return 0, err
+	}
+	var sum neumaierSum
+	for i := 1; i < len(x); i++ {
+		sum.add(0.5 * (t[i] - t[i-1]) * (x[i] + x[i-1]))
+	}
+	return sum.total(), nil
+}
+
+// checkTimes returns the times t of the observations x, the observation
+// index when t is nil, or an error unless there are at least two
+// observations at strictly increasing times.
+func checkTimes(t, x []float64) ([]float64, error) {
+	if t != nil && len(t) != len(x) {
+		return nil, ErrLengthMismatch
+	}
+	if len(x) < 2 {
+		return nil, ErrEmptyInput
+	}
+	if t == nil {
+		t = make([]float64, len(x))
+		for i := 0; i < len(x); i++ {
+			t[i] = float64(i)
+		}
+	}
+	for i := 1; i < len(t); i++ {
+		if !(t[i] > t[i-1]) {
+			return nil, ErrInvalidParameter
+		}
+	}
+	return t, nil
+}
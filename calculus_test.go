@@ -0,0 +1,50 @@
+package gostat
+
+import (
+	"testing"
+)
+
+func TestDerivative(t *testing.T) {
+	ts := []float64{0., 1., 3., 4., 7.}
+	x := make([]float64, len(ts))
+	for i := 0; i < len(ts); i++ {
+		x[i] = ts[i] * ts[i]
+	}
+	d, err := Derivative(ts, x, 0)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	// central differences are exact for a quadratic inside the series
+	compareArrays([]float64{1., 2., 6., 8., 11.}, d, t)
+
+	d, err = Derivative(nil, []float64{1., 3., 4., 8., 9., 11.}, 3)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	compareArrays([]float64{1.5, 1.5, 2.5, 2.5, 1.5, 1.5}, d, t)
+}
+
+func TestIntegrate(t *testing.T) {
+	got, err := Integrate([]float64{0., 1., 3., 4.}, []float64{2., 4., 0., 1.})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if want := 7.5; !floatEquals(got, want) {
+		t.Errorf("Expected integral=%f, got=%f", want, got)
+	}
+	if got, _ := Integrate(nil, []float64{1., 1., 1.}); !floatEquals(got, 2.) {
+		t.Errorf("Expected integral=%f, got=%f", 2., got)
+	}
+}
+
+func TestCalculus_Errors(t *testing.T) {
+	if _, err := Derivative([]float64{0., 1.}, []float64{1.}, 0); err != ErrLengthMismatch {
+		t.Errorf("Expected error=%v, got=%v", ErrLengthMismatch, err)
+	}
+	if _, err := Integrate(nil, []float64{1.}); err != ErrEmptyInput {
+		t.Errorf("Expected error=%v, got=%v", ErrEmptyInput, err)
+	}
+	if _, err := Integrate([]float64{0., 0.}, []float64{1., 2.}); err != ErrInvalidParameter {
+		t.Errorf("Expected error=%v, got=%v", ErrInvalidParameter, err)
+	}
+}
@@ -0,0 +1,131 @@
+package gostat
+
+import (
+	"math"
+)
+
+// BrierScore returns the Brier score of probability forecasts of binary
+// outcomes, the mean squared difference between each forecast probability
+// and 1 for an event that occurred or 0 for one that did not, from 0 for
+// perfect forecasts to 1. Forecasts with a NaN value are ignored.
+func BrierScore(probs []float64, outcomes []bool) float64 {
+	if len(probs) != len(outcomes) {
+		panic("gostat: slice length mismatch")
+	}
+	var sum neumaierSum
+	var n int
+	for i := 0; i < len(probs); i++ {
+		if math.IsNaN(probs[i]) {
+			continue
+		}
+		d := probs[i] - outcome(outcomes[i])
+		sum.add(d * d)
+		n++
+	}
+	if n == 0 {
+		return math.NaN()
+	}
+	return sum.total() / float64(n)
+}
+
+// CalibrationBin is a bin of the reliability curve of probability
+// forecasts, returned by CalibrationCurve.
+type CalibrationBin struct {
+	// Lower and Upper bound the forecast probabilities in the bin.
+	Lower, Upper float64
+	Count        int
+	// Predicted is the mean forecast probability in the bin, and Observed
+	// the fraction of the outcomes in the bin which occurred.
+	Predicted, Observed float64
+}
+
+// CalibrationCurve returns the reliability curve of probability forecasts of
+// binary outcomes, the forecasts grouped into bins equal width bins of the
+// [0, 1] range, each but the last holding the forecasts at or above its
+// lower bound and below its upper bound. Only bins holding a forecast are
+// returned. The forecasts are well calibrated when the observed frequency
+// of the events in each bin matches their mean predicted probability, and
+// plotting one against the other shows where they are over- or
+// underconfident. Forecasts with a NaN value are ignored, and forecasts
+// outside of the [0, 1] range are put into the first or the last bin.
+func CalibrationCurve(probs []float64, outcomes []bool, bins int) []CalibrationBin {
+	if len(probs) != len(outcomes) {
+		panic("gostat: slice length mismatch")
+	}
+	if bins < 1 {
+		return nil
+	}
+	all := make([]CalibrationBin, bins)
+	for i := 0; i < len(probs); i++ {
+		if math.IsNaN(probs[i]) {
+			continue
+		}
+		k := minInt(maxInt(int(probs[i]*float64(bins)), 0), bins-1)
+		all[k].Count++
+		all[k].Predicted += probs[i]
+		all[k].Observed += outcome(outcomes[i])
+	}
+	var curve []CalibrationBin
+	for k := 0; k < bins; k++ {
+		b := all[k]
+		if b.Count == 0 {
+			continue
+		}
+		b.Lower, b.Upper = float64(k)/float64(bins), float64(k+1)/float64(bins)
+		b.Predicted /= float64(b.Count)
+		b.Observed /= float64(b.Count)
+		curve = append(curve, b)
+	}
+	return curve
+}
+
+// BrierDecomposition is the Murphy decomposition of the Brier score,
+// returned by BrierDecompose.
+type BrierDecomposition struct {
+	Brier float64
+	// Reliability is the mean squared difference between the predicted
+	// probability and the observed frequency of the events in each bin, 0
+	// for perfectly calibrated forecasts.
+	Reliability float64
+	// Resolution is the mean squared difference between the observed
+	// frequency in each bin and the overall frequency of the events, which
+	// grows as the forecasts separate events from non-events.
+	Resolution float64
+	// Uncertainty is the variance of the outcomes, o*(1-o) for an overall
+	// frequency of the events o, the Brier score of always forecasting o.
+	Uncertainty float64
+}
+
+// BrierDecompose returns the Brier score of probability forecasts of binary
+// outcomes with its decomposition into reliability, resolution and
+// uncertainty over the bins of CalibrationCurve, where the score is close
+// to Reliability-Resolution+Uncertainty, exactly so when the forecasts in
+// each bin are equal. Forecasts with a NaN value are ignored.
+func BrierDecompose(probs []float64, outcomes []bool, bins int) BrierDecomposition {
+	d := BrierDecomposition{Brier: BrierScore(probs, outcomes)}
+	curve := CalibrationCurve(probs, outcomes, bins)
+	var n, events float64
+	for _, b := range curve {
+		n += float64(b.Count)
+		events += b.Observed * float64(b.Count)
+	}
+	if n == 0 {
+		nan := math.NaN()
+		return BrierDecomposition{Brier: nan, Reliability: nan, Resolution: nan, Uncertainty: nan}
+	}
+	rate := events / n
+	for _, b := range curve {
+		w := float64(b.Count) / n
+		d.Reliability += w * (b.Predicted - b.Observed) * (b.Predicted - b.Observed)
+		d.Resolution += w * (b.Observed - rate) * (b.Observed - rate)
+	}
+	d.Uncertainty = rate * (1 - rate)
+	return d
+}
+
+func outcome(occurred bool) float64 {
+	if occurred {
+		return 1
+	}
+	return 0
+}
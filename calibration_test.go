@@ -0,0 +1,45 @@
+package gostat
+
+import (
+	"math"
+	"testing"
+)
+
+func TestBrierScore(t *testing.T) {
+	probs := []float64{0.9, 0.2, 0.6, math.NaN(), 0.1}
+	outcomes := []bool{true, false, false, true, true}
+	if got, want := BrierScore(probs, outcomes), 0.3050; !floatEquals(got, want) {
+		t.Errorf("Expected Brier score=%f, got=%f", want, got)
+	}
+	if got := BrierScore(nil, nil); !math.IsNaN(got) {
+		t.Errorf("Expected Brier score=NaN, got=%f", got)
+	}
+}
+
+func TestCalibrationCurve(t *testing.T) {
+	probs := []float64{0.1, 0.2, 0.15, 0.8, 0.9, 0.7, 1.}
+	outcomes := []bool{false, true, false, true, true, false, true}
+	curve := CalibrationCurve(probs, outcomes, 2)
+	if len(curve) != 2 {
+		t.Fatalf("Expected 2 bins, got=%v", curve)
+	}
+	if curve[0].Count != 3 || curve[1].Count != 4 {
+		t.Errorf("Expected bins of 3 and 4 forecasts, got=%d and %d", curve[0].Count, curve[1].Count)
+	}
+	compareArrays([]float64{0., 0.5, 0.15, 0.3333}, []float64{curve[0].Lower, curve[0].Upper, curve[0].Predicted, curve[0].Observed}, t)
+	compareArrays([]float64{0.5, 1., 0.85, 0.75}, []float64{curve[1].Lower, curve[1].Upper, curve[1].Predicted, curve[1].Observed}, t)
+	if got := CalibrationCurve(probs, outcomes, 10); len(got) != 5 {
+		t.Errorf("Expected only non-empty bins, got=%v", got)
+	}
+}
+
+func TestBrierDecompose(t *testing.T) {
+	// equal forecasts in each bin make the decomposition exact
+	probs := []float64{0.2, 0.2, 0.2, 0.2, 0.2, 0.7, 0.7, 0.7, 0.7}
+	outcomes := []bool{false, false, true, false, false, true, true, false, true}
+	d := BrierDecompose(probs, outcomes, 10)
+	compareArrays([]float64{0.1733, 0.0011, 0.0747, 0.2469}, []float64{d.Brier, d.Reliability, d.Resolution, d.Uncertainty}, t)
+	if got, want := d.Reliability-d.Resolution+d.Uncertainty, d.Brier; !floatEquals(got, want) {
+		t.Errorf("Expected decomposition=%f, got=%f", want, got)
+	}
+}
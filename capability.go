@@ -0,0 +1,103 @@
+package gostat
+
+import (
+	"math"
+)
+
+// d2 holds the control chart constant d2, the mean range of n standard
+// normal values, for subgroups of n = 2 to 10.
+var d2 = []float64{2: 1.128, 1.693, 2.059, 2.326, 2.534, 2.704, 2.847, 2.970, 3.078}
+
+// ProcessCapability holds the capability indices of a process returned by
+// Capability.
+type ProcessCapability struct {
+	// Mean is the process mean.
+	Mean float64
+	// SigmaWithin is the short-term standard deviation estimated from the
+	// variation within subgroups, and SigmaOverall the long-term sample
+	// standard deviation of all values.
+	SigmaWithin, SigmaOverall float64
+	// Cp and Cpk are the potential and the actual capability, based on
+	// SigmaWithin.
+	Cp, Cpk float64
+	// Pp and Ppk are the potential and the actual performance, based on
+	// SigmaOverall.
+	Pp, Ppk float64
+}
+
+// Capability returns the capability indices of a process with values x
+// against the lower and upper specification limits lsl and usl. The values
+// are taken in consecutive subgroups of the given size, from 1 to 10, and
+// the within sigma is estimated from the mean subgroup range, or from the
+// mean moving range of consecutive values for subgroups of size 1. For a
+// one-sided specification pass an infinite limit, in which case Cp and Pp
+// are NaN. ErrInvalidParameter is returned for an invalid subgroup size or
+// limits, and ErrEmptyInput for fewer than two subgroups.
+func Capability(x []float64, lsl, usl float64, subgroup int) (ProcessCapability, error) {
+	if subgroup < 1 || subgroup >= len(d2) || !(lsl < usl) {
+		return ProcessCapability{}, ErrInvalidParameter
+	}
+	sigma, err := sigmaWithin(x, subgroup)
+	if err != nil {
+		return ProcessCapability{}, err
+	}
+	c := ProcessCapability{
+		Mean:         Mean(x, nil),
+		SigmaWithin:  sigma,
+		SigmaOverall: StdDev(x, nil, VarianceTwoPass),
+	}
+	c.Cp, c.Cpk = capabilityIndices(c.Mean, c.SigmaWithin, lsl, usl)
+	c.Pp, c.Ppk = capabilityIndices(c.Mean, c.SigmaOverall, lsl, usl)
+	return c, nil
+}
+
+func capabilityIndices(mean, sigma, lsl, usl float64) (cp, cpk float64) {
+	cp = (usl - lsl) / (6 * sigma)
+	if math.IsInf(lsl, 0) || math.IsInf(usl, 0) {
+		cp = math.NaN()
+	}
+	cpk = math.Min(usl-mean, mean-lsl) / (3 * sigma)
+	return cp, cpk
+}
+
+// sigmaWithin estimates the short-term standard deviation of x from the
+// mean range of consecutive subgroups of the given size, or from the mean
+// moving range for subgroups of size 1.
+func sigmaWithin(x []float64, subgroup int) (float64, error) {
+	ranges := subgroupRanges(x, subgroup)
+	// a moving range is the range of a subgroup of two values
+	n, min := subgroup, 2
+	if subgroup == 1 {
+		n, min = 2, 1
+	}
+	if len(ranges) < min {
+		return 0, ErrEmptyInput
+	}
+	return Mean(ranges, nil) / d2[n], nil
+}
+
+// subgroupRanges returns the ranges of the consecutive subgroups of x of the
+// given size, ignoring an incomplete last subgroup, or the moving ranges of
+// consecutive values for subgroups of size 1.
+func subgroupRanges(x []float64, subgroup int) []float64 {
+	if subgroup == 1 {
+		if len(x) < 2 {
+			return nil
+		}
+		ranges := make([]float64, len(x)-1)
+		for i := 1; i < len(x); i++ {
+			ranges[i-1] = math.Abs(x[i] - x[i-1])
+		}
+		return ranges
+	}
+	ranges := make([]float64, 0, len(x)/subgroup)
+	for i := 0; i+subgroup <= len(x); i += subgroup {
+		lo, hi := x[i], x[i]
+		for j := i + 1; j < i+subgroup; j++ {
+			lo = math.Min(lo, x[j])
+			hi = math.Max(hi, x[j])
+		}
+		ranges = append(ranges, hi-lo)
+	}
+	return ranges
+}
@@ -0,0 +1,70 @@
+package gostat
+
+import (
+	"math"
+	"testing"
+)
+
+var testProcess = []float64{10.1, 9.9, 10., 10.2, 9.8, 10.1, 10., 9.9, 10.3, 10., 9.7, 10.1}
+
+func TestCapability(t *testing.T) {
+	c, err := Capability(testProcess, 9.4, 10.6, 1)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	checks := []struct {
+		name      string
+		got, want float64
+	}{
+		{"mean", c.Mean, 10.0083},
+		{"sigma within", c.SigmaWithin, 0.2257},
+		{"sigma overall", c.SigmaOverall, 0.1676},
+		{"Cp", c.Cp, 0.8863},
+		{"Cpk", c.Cpk, 0.8740},
+		{"Pp", c.Pp, 1.1930},
+		{"Ppk", c.Ppk, 1.1764},
+	}
+	for _, ch := range checks {
+		if !floatEquals(ch.got, ch.want) {
+			t.Errorf("Expected %s=%f, got=%f", ch.name, ch.want, ch.got)
+		}
+	}
+}
+
+func TestCapability_Subgroups(t *testing.T) {
+	c, err := Capability(testProcess, 9.4, 10.6, 3)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if got, want := c.SigmaWithin, 0.2067; !floatEquals(got, want) {
+		t.Errorf("Expected sigma within=%f, got=%f", want, got)
+	}
+	if got, want := c.Cp, 0.9674; !floatEquals(got, want) {
+		t.Errorf("Expected Cp=%f, got=%f", want, got)
+	}
+}
+
+func TestCapability_OneSided(t *testing.T) {
+	c, err := Capability(testProcess, math.Inf(-1), 10.6, 1)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !math.IsNaN(c.Cp) {
+		t.Errorf("Expected Cp=NaN, got=%f", c.Cp)
+	}
+	if got, want := c.Cpk, (10.6-c.Mean)/(3*c.SigmaWithin); !floatEquals(got, want) {
+		t.Errorf("Expected Cpk=%f, got=%f", want, got)
+	}
+}
+
+func TestCapability_Errors(t *testing.T) {
+	if _, err := Capability(testProcess, 10.6, 9.4, 1); err != ErrInvalidParameter {
+		t.Errorf("Expected error=%v, got=%v", ErrInvalidParameter, err)
+	}
+	if _, err := Capability(testProcess, 9.4, 10.6, 11); err != ErrInvalidParameter {
+		t.Errorf("Expected error=%v, got=%v", ErrInvalidParameter, err)
+	}
+	if _, err := Capability(testProcess[:5], 9.4, 10.6, 5); err != ErrEmptyInput {
+		t.Errorf("Expected error=%v, got=%v", ErrEmptyInput, err)
+	}
+}
@@ -0,0 +1,96 @@
+package gostat
+
+import (
+	"math"
+)
+
+// PeriodAngles maps the values of x with the given period onto angles in
+// radians, for the circular statistics of periodic data such as times of
+// day in hours with a period of 24 or days of the week with a period of 7.
+// An angle a maps back onto a value a*period/(2*pi).
+func PeriodAngles(x []float64, period float64) []float64 {
+	angles := make([]float64, len(x))
+	for i := 0; i < len(x); i++ {
+		angles[i] = 2 * math.Pi * math.Mod(x[i]/period, 1)
+		if angles[i] < 0 {
+			angles[i] += 2 * math.Pi
+		}
+	}
+	return angles
+}
+
+// CircularMean returns the mean direction of angles in radians, in the
+// range [0, 2*pi), the direction of the resultant of the unit vectors at
+// each angle. Unlike the arithmetic mean it treats angles a full turn apart
+// as equal, so that the mean of events at 23:00 and 01:00 is midnight
+// rather than noon. The vectors are weighted by weights, or equally when
+// weights is nil. NaN angles are ignored, and the mean is NaN when the
+// resultant is zero.
+func CircularMean(angles, weights []float64) float64 {
+	c, s, n := resultant(angles, weights)
+	if !(math.Hypot(c, s) > 1e-12*n) {
+		return math.NaN()
+	}
+	mean := math.Atan2(s, c)
+	if mean < 0 {
+		mean += 2 * math.Pi
+	}
+	return mean
+}
+
+// CircularVariance returns the circular variance of angles in radians,
+// 1-R for the mean resultant length R, from 0 when all angles are equal to
+// 1 when they are spread evenly around the circle. NaN angles are ignored.
+func CircularVariance(angles []float64) float64 {
+	c, s, n := resultant(angles, nil)
+	if n == 0 {
+		return math.NaN()
+	}
+	return 1 - math.Hypot(c, s)/n
+}
+
+// CircularStdDev returns the circular standard deviation of angles in
+// radians, sqrt(-2*ln(R)) for the mean resultant length R, which is close
+// to the standard deviation of angles concentrated around their mean and
+// infinite for angles spread evenly around the circle. NaN angles are
+// ignored.
+func CircularStdDev(angles []float64) float64 {
+	return math.Sqrt(-2 * math.Log(1-CircularVariance(angles)))
+}
+
+// RayleighTest returns the statistic Z = n*R^2 and the p-value of the
+// Rayleigh test of the uniformity of angles in radians, against a
+// unimodal alternative, such as whether events cluster at some time of the
+// day, for the mean resultant length R of n angles. The p-value uses
+// Zar's approximation. NaN angles are ignored.
+func RayleighTest(angles []float64) (z, pValue float64) {
+	c, s, n := resultant(angles, nil)
+	if n == 0 {
+		return math.NaN(), math.NaN()
+	}
+	r := math.Hypot(c, s)
+	z = r * r / n
+	pValue = math.Exp(math.Sqrt(1+4*n+4*(n*n-r*r)) - (1 + 2*n))
+	return z, math.Min(pValue, 1)
+}
+
+// resultant returns the sums of the cosines and of the sines of angles, and
+// the number of angles, weighted by weights unless it is nil.
+func resultant(angles, weights []float64) (c, s, n float64) {
+	if weights != nil {
+		checkSeries([][]float64{angles, weights})
+	}
+	for i := 0; i < len(angles); i++ {
+		if math.IsNaN(angles[i]) {
+			continue
+		}
+		w := 1.0
+		if weights != nil {
+			w = weights[i]
+		}
+		c += w * math.Cos(angles[i])
+		s += w * math.Sin(angles[i])
+		n += w
+	}
+	return c, s, n
+}
@@ -0,0 +1,48 @@
+package gostat
+
+import (
+	"math"
+	"testing"
+)
+
+func TestPeriodAngles(t *testing.T) {
+	compareArrays([]float64{0., math.Pi / 2, math.Pi, 3 * math.Pi / 2, math.Pi / 2}, PeriodAngles([]float64{0., 6., 36., -6., 30.}, 24), t)
+}
+
+func TestCircularMean(t *testing.T) {
+	angles := PeriodAngles([]float64{23., 1., math.NaN()}, 24)
+	if got := CircularMean(angles, nil); !floatEquals(got, 0) && !floatEquals(got, 2*math.Pi) {
+		t.Errorf("Expected mean=0, got=%f", got)
+	}
+	if got, want := CircularMean([]float64{0., math.Pi / 2}, []float64{1., 3.}), math.Atan2(3, 1); !floatEquals(got, want) {
+		t.Errorf("Expected mean=%f, got=%f", want, got)
+	}
+	if got := CircularMean([]float64{0., math.Pi}, nil); !math.IsNaN(got) {
+		t.Errorf("Expected mean=NaN, got=%f", got)
+	}
+}
+
+func TestCircularVariance(t *testing.T) {
+	angles := []float64{0.1, 0.2, 0.3}
+	if got, want := CircularVariance(angles), 0.0033; !floatEquals(got, want) {
+		t.Errorf("Expected variance=%f, got=%f", want, got)
+	}
+	if got, want := CircularStdDev(angles), 0.0817; !floatEquals(got, want) {
+		t.Errorf("Expected std dev=%f, got=%f", want, got)
+	}
+	if got, want := CircularVariance([]float64{0., math.Pi / 2, math.Pi, 3 * math.Pi / 2}), 1.; !floatEquals(got, want) {
+		t.Errorf("Expected variance=%f, got=%f", want, got)
+	}
+}
+
+func TestRayleighTest(t *testing.T) {
+	clustered := PeriodAngles([]float64{8., 9., 9.5, 10., 8.5, 9., 11., 7.5, 9., 10.}, 24)
+	z, p := RayleighTest(clustered)
+	if z < 8 || p > 0.001 {
+		t.Errorf("Expected clustered angles, got z=%f p=%f", z, p)
+	}
+	z, p = RayleighTest(PeriodAngles([]float64{0., 3., 6., 9., 12., 15., 18., 21.}, 24))
+	if !floatEquals(z, 0) || p < 0.99 {
+		t.Errorf("Expected uniform angles, got z=%f p=%f", z, p)
+	}
+}
@@ -0,0 +1,64 @@
+package gostat
+
+import (
+	"math"
+)
+
+// Sum returns the sum of x, using Neumaier's compensated summation to keep
+// the rounding error independent of the length of x.
+func Sum(x []float64) float64 {
+	var s neumaierSum
+	for i := 0; i < len(x); i++ {
+		s.add(x[i])
+	}
+	return s.total()
+}
+
+// Mean returns the weighted arithmetic mean of x, with the sums of the
+// weighted values and of the weights compensated like Sum. Weights may be
+// nil for equal weights. NaN is returned for an empty slice.
+func Mean(x, weights []float64) float64 {
+	if weights == nil {
+		return Sum(x) / float64(len(x))
+	}
+	if len(weights) != len(x) {
+		panic("gostat: slice length mismatch")
+	}
+	return Dot(x, weights) / Sum(weights)
+}
+
+// Dot returns the dot product of x and y, the sum of their element-wise
+// products compensated like Sum.
+func Dot(x, y []float64) float64 {
+	if len(x) != len(y) {
+		panic("gostat: slice length mismatch")
+	}
+	var s neumaierSum
+	for i := 0; i < len(x); i++ {
+		s.add(x[i] * y[i])
+	}
+	return s.total()
+}
+
+// neumaierSum is a running sum with Neumaier's compensation, an improved
+// Kahan summation that also handles terms larger than the running sum.
+type neumaierSum struct {
+	hi, lo float64
+}
+
+func (s *neumaierSum) add(x float64) {
+	t := s.hi + x
+	if math.Abs(s.hi) >= math.Abs(x) {
+		s.lo += (s.hi - t) + x
+	} else {
+		s.lo += (x - t) + s.hi
+	}
+	s.hi = t
+}
+
+func (s *neumaierSum) total() float64 {
+	if math.IsNaN(s.hi) || math.IsInf(s.hi, 0) {
+		return s.hi
+	}
+	return s.hi + s.lo
+}
@@ -0,0 +1,45 @@
+package gostat
+
+import (
+	"math"
+	"testing"
+)
+
+func TestSum(t *testing.T) {
+	x := []float64{1., 1e100, 1., -1e100}
+	if got, want := Sum(x), 2.; got != want {
+		t.Errorf("Expected sum=%f, got=%f", want, got)
+	}
+	x = make([]float64, 10000)
+	for i := 0; i < len(x); i++ {
+		x[i] = 0.1
+	}
+	if got, want := Sum(x), 1000.; got != want {
+		t.Errorf("Expected sum=%.15f, got=%.15f", want, got)
+	}
+	if got := Sum([]float64{1., math.Inf(1)}); !math.IsInf(got, 1) {
+		t.Errorf("Expected sum=+Inf, got=%f", got)
+	}
+	if got := Sum([]float64{math.NaN(), 1.}); !math.IsNaN(got) {
+		t.Errorf("Expected sum=NaN, got=%f", got)
+	}
+}
+
+func TestMean(t *testing.T) {
+	x := []float64{1e9 + 0.1, 1e9 + 0.2, 1e9 + 0.3}
+	if got, want := Mean(x, nil), 1e9+0.2; math.Abs(got-want) > 1e-6 {
+		t.Errorf("Expected mean=%f, got=%f", want, got)
+	}
+	if got, want := Mean([]float64{1., 2., 4.}, []float64{1., 1., 2.}), 2.75; !floatEquals(got, want) {
+		t.Errorf("Expected mean=%f, got=%f", want, got)
+	}
+	if got := Mean(nil, nil); !math.IsNaN(got) {
+		t.Errorf("Expected mean=NaN, got=%f", got)
+	}
+}
+
+func TestDot(t *testing.T) {
+	if got, want := Dot([]float64{1e100, 1., -1e100}, []float64{1., 3., 1.}), 3.; got != want {
+		t.Errorf("Expected dot=%f, got=%f", want, got)
+	}
+}
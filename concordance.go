@@ -0,0 +1,152 @@
+package gostat
+
+import (
+	"math"
+	"sort"
+)
+
+// KendallTau returns Kendall's tau-b rank correlation of x and y, the
+// difference between the numbers of concordant and discordant pairs of
+// observations, adjusted for ties in either series, between -1 and 1. It
+// measures the agreement of two rankings, such as of assets by a signal and
+// by their subsequent returns, and is not affected by any monotone
+// transformation of either series. Observations with a NaN value are
+// ignored. The pairs are counted in O(n log n) time by Knight's algorithm.
+func KendallTau(x, y []float64) float64 {
+	c := countPairs(x, y)
+	return c.diff / math.Sqrt((c.total-c.tiedX)*(c.total-c.tiedY))
+}
+
+// SomersD returns Somers' D of x with respect to y, the difference between
+// the numbers of concordant and discordant pairs of observations divided by
+// the number of pairs with different values of y. With x the predictions of
+// a model and y the outcomes it is Harrell's Dxy, 2*AUC-1 for binary
+// outcomes, between -1 for a perfectly reversed ranking and 1 for a perfect
+// one. Observations with a NaN value are ignored.
+func SomersD(x, y []float64) float64 {
+	c := countPairs(x, y)
+	return c.diff / (c.total - c.tiedY)
+}
+
+// AUC returns the area under the ROC curve of scores predicting binary
+// labels, also known as the concordance or C-statistic, the probability that
+// a randomly chosen positive observation scores higher than a randomly
+// chosen negative one, with ties counting half. It is 0.5 for a random
+// ranking and 1 for a perfect one. Scores with a NaN value are ignored, and
+// the AUC is NaN without both positive and negative labels.
+func AUC(scores []float64, labels []bool) float64 {
+	if len(scores) != len(labels) {
+		panic("gostat: slice length mismatch")
+	}
+	var s []float64
+	var pos []bool
+	for i := 0; i < len(scores); i++ {
+		if !math.IsNaN(scores[i]) {
+			s = append(s, scores[i])
+			pos = append(pos, labels[i])
+		}
+	}
+	r := ranks(s)
+	var np, nn, sum float64
+	for i := 0; i < len(r); i++ {
+		if pos[i] {
+			np++
+			sum += r[i]
+		} else {
+			nn++
+		}
+	}
+	if np == 0 || nn == 0 {
+		return math.NaN()
+	}
+	return (sum - np*(np+1)/2) / (np * nn)
+}
+
+// pairCounts holds the numbers of pairs of observations in total, tied in x,
+// tied in y and tied in both, and the difference between the numbers of
+// concordant and discordant pairs.
+type pairCounts struct {
+	total, tiedX, tiedY, tiedXY, diff float64
+}
+
+func countPairs(x, y []float64) pairCounts {
+	checkSeries([][]float64{x, y})
+	var idx []int
+	for i := 0; i < len(x); i++ {
+		if !math.IsNaN(x[i]) && !math.IsNaN(y[i]) {
+			idx = append(idx, i)
+		}
+	}
+	sort.Sort(byPair{idx, x, y})
+
+	var c pairCounts
+	n := float64(len(idx))
+	c.total = n * (n - 1) / 2
+	ys := make([]float64, len(idx))
+	for i := 0; i < len(idx); i++ {
+		ys[i] = y[idx[i]]
+	}
+	c.tiedX = tiedPairs(len(idx), func(i int) bool { return x[idx[i]] == x[idx[i-1]] })
+	c.tiedXY = tiedPairs(len(idx), func(i int) bool {
+		return x[idx[i]] == x[idx[i-1]] && ys[i] == ys[i-1]
+	})
+	// ys is sorted by y within ties in x, so that only the discordant pairs
+	// are out of order
+	discordant := mergeCount(ys, make([]float64, len(ys)))
+	c.tiedY = tiedPairs(len(ys), func(i int) bool { return ys[i] == ys[i-1] })
+	c.diff = c.total - c.tiedX - c.tiedY + c.tiedXY - 2*discordant
+	return c
+}
+
+// tiedPairs returns the number of pairs within the runs of n consecutive
+// elements, where tied reports whether element i is tied with element i-1.
+func tiedPairs(n int, tied func(i int) bool) float64 {
+	var pairs, run float64
+	for i := 1; i < n; i++ {
+		if tied(i) {
+			run++
+			pairs += run
+		} else {
+			run = 0
+		}
+	}
+	return pairs
+}
+
+// mergeCount sorts x by merge sort using buf of the same length, and returns
+// the number of pairs of elements that were out of order.
+func mergeCount(x, buf []float64) float64 {
+	if len(x) < 2 {
+		return 0
+	}
+	mid := len(x) / 2
+	count := mergeCount(x[:mid], buf[:mid]) + mergeCount(x[mid:], buf[mid:])
+	i, j, k := 0, mid, 0
+	for i < mid && j < len(x) {
+		if x[j] < x[i] {
+			buf[k] = x[j]
+			count += float64(mid - i)
+			j++
+		} else {
+			buf[k] = x[i]
+			i++
+		}
+		k++
+	}
+	k += copy(buf[k:], x[i:mid])
+	copy(buf[k:], x[j:])
+	copy(x, buf)
+	return count
+}
+
+type byPair struct {
+	idx  []int
+	x, y []float64
+}
+
+func (b byPair) Len() int { return len(b.idx) }
+func (b byPair) Less(i, j int) bool {
+	xi, xj := b.x[b.idx[i]], b.x[b.idx[j]]
+	return xi < xj || (xi == xj && b.y[b.idx[i]] < b.y[b.idx[j]])
+}
+func (b byPair) Swap(i, j int) { b.idx[i], b.idx[j] = b.idx[j], b.idx[i] }
@@ -0,0 +1,67 @@
+package gostat
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func TestKendallTau(t *testing.T) {
+	x := []float64{1., 2., 3., 4., 5.}
+	if got, want := KendallTau(x, []float64{3., 1., 2., 5., 4.}), 0.4; !floatEquals(got, want) {
+		t.Errorf("Expected tau=%f, got=%f", want, got)
+	}
+	if got, want := KendallTau(x, []float64{5., 4., 3., 2., 1.}), -1.; !floatEquals(got, want) {
+		t.Errorf("Expected tau=%f, got=%f", want, got)
+	}
+	// ties in both series
+	if got, want := KendallTau([]float64{1., 1., 2., 3., math.NaN()}, []float64{1., 2., 2., 3., 1.}), 0.8; !floatEquals(got, want) {
+		t.Errorf("Expected tau=%f, got=%f", want, got)
+	}
+}
+
+func TestKendallTau_Naive(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	x := make([]float64, 60)
+	y := make([]float64, 60)
+	for i := 0; i < len(x); i++ {
+		x[i] = float64(rnd.Intn(8))
+		y[i] = float64(rnd.Intn(5)) + x[i]/4
+	}
+	var diff, tx, ty, n float64
+	for i := 0; i < len(x); i++ {
+		for j := i + 1; j < len(x); j++ {
+			s := sign(x[i]-x[j]) * sign(y[i]-y[j])
+			diff += s
+			if x[i] == x[j] {
+				tx++
+			}
+			if y[i] == y[j] {
+				ty++
+			}
+			n++
+		}
+	}
+	if got, want := KendallTau(x, y), diff/math.Sqrt((n-tx)*(n-ty)); !floatEquals(got, want) {
+		t.Errorf("Expected tau=%f, got=%f", want, got)
+	}
+	if got, want := SomersD(x, y), diff/(n-ty); !floatEquals(got, want) {
+		t.Errorf("Expected D=%f, got=%f", want, got)
+	}
+}
+
+func TestAUC(t *testing.T) {
+	scores := []float64{0.9, 0.8, 0.7, 0.7, 0.5, 0.3, math.NaN()}
+	labels := []bool{true, false, true, false, true, false, true}
+	// 5 of 9 pairs concordant and one tied
+	if got, want := AUC(scores, labels), 5.5/9; !floatEquals(got, want) {
+		t.Errorf("Expected AUC=%f, got=%f", want, got)
+	}
+	y := []float64{1., 0., 1., 0., 1., 0.}
+	if got, want := SomersD(scores[:6], y), 2*5.5/9-1; !floatEquals(got, want) {
+		t.Errorf("Expected D=%f, got=%f", want, got)
+	}
+	if got := AUC([]float64{1., 2.}, []bool{true, true}); !math.IsNaN(got) {
+		t.Errorf("Expected AUC=NaN, got=%f", got)
+	}
+}
@@ -0,0 +1,88 @@
+package gostat
+
+import (
+	"github.com/gonum/stat/distuv"
+	"math"
+)
+
+// ContingencyTest is the result of ContingencyTable.
+type ContingencyTest struct {
+	// ChiSquare is Pearson's chi-square statistic of independence of the
+	// rows and the columns, and PValue its p-value with DF degrees of
+	// freedom.
+	ChiSquare, PValue float64
+	DF                int
+	// CramersV is the strength of the association, between 0 for
+	// independence and 1 when either variable determines the other.
+	CramersV float64
+	// Phi is the phi coefficient of a 2x2 table, the correlation of the two
+	// binary variables between -1 and 1, positive when the counts
+	// concentrate on the diagonal. It is NaN for larger tables.
+	Phi float64
+}
+
+// ContingencyTable tests the independence of two categorical variables from
+// their r x c contingency table, where table[i][j] is the number of
+// observations in row category i and column category j, and measures the
+// size of their association, which unlike the p-value does not grow with the
+// number of observations. ErrLengthMismatch is returned when the rows have
+// different lengths, and ErrInvalidParameter for fewer than two rows or
+// columns, a negative count or a row or a column without observations.
+func ContingencyTable(table [][]float64) (ContingencyTest, error) {
+	var res ContingencyTest
+	if len(table) < 2 || len(table[0]) < 2 {
+		return res, ErrInvalidParameter
+	}
+	r, c := len(table), len(table[0])
+	rows := make([]float64, r)
+	cols := make([]float64, c)
+	var n float64
+	for i := 0; i < r; i++ {
+		if len(table[i]) != c {
+			return res, ErrLengthMismatch
+		}
+		for j := 0; j < c; j++ {
+			if !(table[i][j] >= 0) {
+				return res, ErrInvalidParameter
+			}
+			rows[i] += table[i][j]
+			cols[j] += table[i][j]
+			n += table[i][j]
+		}
+	}
+	for i := 0; i < r; i++ {
+		for j := 0; j < c; j++ {
+			if rows[i] == 0 || cols[j] == 0 {
+				return res, ErrInvalidParameter
+			}
+			e := rows[i] * cols[j] / n
+			res.ChiSquare += (table[i][j] - e) * (table[i][j] - e) / e
+		}
+	}
+	res.DF = (r - 1) * (c - 1)
+	res.PValue = 1 - distuv.ChiSquared{K: float64(res.DF)}.CDF(res.ChiSquare)
+	res.CramersV = math.Sqrt(res.ChiSquare / (n * float64(minInt(r, c)-1)))
+	res.Phi = math.NaN()
+	if r == 2 && c == 2 {
+		a, b, cc, d := table[0][0], table[0][1], table[1][0], table[1][1]
+		res.Phi = (a*d - b*cc) / math.Sqrt(rows[0]*rows[1]*cols[0]*cols[1])
+	}
+	return res, nil
+}
+
+// OddsRatio returns the odds ratio of a 2x2 contingency table, ad/bc for the
+// table {{a, b}, {c, d}}, the odds of the first column in the first row
+// divided by its odds in the second row, with its confidence interval at
+// the given confidence level, such as 0.95, by Woolf's logit method. When a
+// count is zero 0.5 is added to every count, so that the ratio and its
+// interval are finite.
+func OddsRatio(table [2][2]float64, confidence float64) (ratio, lo, hi float64) {
+	a, b, c, d := table[0][0], table[0][1], table[1][0], table[1][1]
+	if a == 0 || b == 0 || c == 0 || d == 0 {
+		a, b, c, d = a+0.5, b+0.5, c+0.5, d+0.5
+	}
+	logRatio := math.Log(a * d / (b * c))
+	se := math.Sqrt(1/a + 1/b + 1/c + 1/d)
+	z := distuv.UnitNormal.Quantile(1 - (1-confidence)/2)
+	return math.Exp(logRatio), math.Exp(logRatio - z*se), math.Exp(logRatio + z*se)
+}
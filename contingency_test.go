@@ -0,0 +1,46 @@
+package gostat
+
+import (
+	"math"
+	"testing"
+)
+
+func TestContingencyTable(t *testing.T) {
+	res, err := ContingencyTable([][]float64{{20., 10.}, {5., 15.}})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	compareArrays([]float64{8.3333, 0.0039, 0.4082, 0.4082}, []float64{res.ChiSquare, res.PValue, res.CramersV, res.Phi}, t)
+	if res.DF != 1 {
+		t.Errorf("Expected DF=1, got=%d", res.DF)
+	}
+
+	res, err = ContingencyTable([][]float64{{10., 10., 10.}, {10., 10., 10.}, {10., 10., 10.}})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if res.ChiSquare != 0 || res.CramersV != 0 || res.DF != 4 || !math.IsNaN(res.Phi) {
+		t.Errorf("Expected independence, got=%+v", res)
+	}
+}
+
+func TestContingencyTable_Errors(t *testing.T) {
+	if _, err := ContingencyTable([][]float64{{1., 2.}, {3.}}); err != ErrLengthMismatch {
+		t.Errorf("Expected error=%v, got=%v", ErrLengthMismatch, err)
+	}
+	if _, err := ContingencyTable([][]float64{{1., 2.}}); err != ErrInvalidParameter {
+		t.Errorf("Expected error=%v, got=%v", ErrInvalidParameter, err)
+	}
+	if _, err := ContingencyTable([][]float64{{1., 0.}, {3., 0.}}); err != ErrInvalidParameter {
+		t.Errorf("Expected error=%v, got=%v", ErrInvalidParameter, err)
+	}
+}
+
+func TestOddsRatio(t *testing.T) {
+	ratio, lo, hi := OddsRatio([2][2]float64{{20., 10.}, {5., 15.}}, 0.95)
+	compareArrays([]float64{6., 1.6932, 21.2618}, []float64{ratio, lo, hi}, t)
+	ratio, _, _ = OddsRatio([2][2]float64{{10., 0.}, {5., 5.}}, 0.95)
+	if want := 10.5 * 5.5 / (0.5 * 5.5); !floatEquals(ratio, want) {
+		t.Errorf("Expected odds ratio=%f, got=%f", want, ratio)
+	}
+}
@@ -0,0 +1,149 @@
+package gostat
+
+import (
+	"math"
+)
+
+// d3 and d4 hold the control chart constants D3 and D4 of the range chart
+// for subgroups of n = 2 to 10.
+var (
+	d3 = []float64{2: 0, 0, 0, 0, 0, 0.076, 0.136, 0.184, 0.223}
+	d4 = []float64{2: 3.267, 2.574, 2.282, 2.114, 2.004, 1.924, 1.864, 1.816, 1.777}
+)
+
+// ControlChart is a statistical process control chart, the values of a
+// charted statistic with their center line and control limits.
+type ControlChart struct {
+	// Values are the charted values.
+	Values []float64
+	// Center is the center line.
+	Center float64
+	// Lower and Upper are the control limits of each value.
+	Lower, Upper []float64
+	// OutOfControl are the indices of the values outside of their control
+	// limits.
+	OutOfControl []int
+}
+
+// XBarRChart returns the X-bar and R charts of the values x taken in
+// consecutive subgroups of the given size, from 2 to 10, with the subgroup
+// means and ranges and the three sigma control limits estimated from the
+// mean range. ErrInvalidParameter is returned for an invalid subgroup size,
+// and ErrEmptyInput for fewer than two subgroups.
+func XBarRChart(x []float64, subgroup int) (xbar, r ControlChart, err error) {
+	if subgroup < 2 || subgroup >= len(d2) {
+		return xbar, r, ErrInvalidParameter
+	}
+	ranges := subgroupRanges(x, subgroup)
+	if len(ranges) < 2 {
+		return xbar, r, ErrEmptyInput
+	}
+	means := make([]float64, len(ranges))
+	for i := 0; i < len(means); i++ {
+		means[i] = Mean(x[i*subgroup:(i+1)*subgroup], nil)
+	}
+	rbar := Mean(ranges, nil)
+	center := Mean(means, nil)
+	a2 := 3 / (d2[subgroup] * math.Sqrt(float64(subgroup)))
+	xbar = newControlChart(means, center, center-a2*rbar, center+a2*rbar)
+	r = newControlChart(ranges, rbar, d3[subgroup]*rbar, d4[subgroup]*rbar)
+	return xbar, r, nil
+}
+
+// IndividualsChart returns the individuals and moving range charts of the
+// values x, with the three sigma control limits estimated from the mean
+// moving range of consecutive values. ErrEmptyInput is returned for fewer
+// than two values.
+func IndividualsChart(x []float64) (individuals, mr ControlChart, err error) {
+	if len(x) < 2 {
+		return individuals, mr, ErrEmptyInput
+	}
+	ranges := subgroupRanges(x, 1)
+	mrbar := Mean(ranges, nil)
+	center := Mean(x, nil)
+	sigma := mrbar / d2[2]
+	individuals = newControlChart(x, center, center-3*sigma, center+3*sigma)
+	mr = newControlChart(ranges, mrbar, d3[2]*mrbar, d4[2]*mrbar)
+	return individuals, mr, nil
+}
+
+// EWMAChart returns the exponentially weighted moving average chart of the
+// values x with smoothing factor lambda in (0, 1] and control limits at L
+// standard deviations of the EWMA, which widen towards their asymptotic
+// value. The EWMA starts from the mean of x, and the standard deviation of
+// x is estimated from its mean moving range. ErrInvalidParameter is
+// returned for an invalid lambda, and ErrEmptyInput for fewer than two
+// values.
+func EWMAChart(x []float64, lambda, L float64) (ControlChart, error) {
+	if !(lambda > 0 && lambda <= 1) {
+		return ControlChart{}, ErrInvalidParameter
+	}
+	sigma, err := sigmaWithin(x, 1)
+	if err != nil {
+		return ControlChart{}, err
+	}
+	center := Mean(x, nil)
+	z := EWMA(append([]float64{center}, x...), lambda)[1:]
+	c := ControlChart{
+		Values: z,
+		Center: center,
+		Lower:  make([]float64, len(z)),
+		Upper:  make([]float64, len(z)),
+	}
+	for i := 0; i < len(z); i++ {
+		w := L * sigma * math.Sqrt(lambda/(2-lambda)*(1-math.Pow(1-lambda, float64(2*(i+1)))))
+		c.Lower[i], c.Upper[i] = center-w, center+w
+	}
+	c.OutOfControl = outOfControl(c)
+	return c, nil
+}
+
+// CUSUMChart returns the upper and lower tabular CUSUM charts of the values
+// x, the cumulative sums of their deviations above and below the mean
+// beyond the allowance k standard deviations, with the decision interval h
+// standard deviations as the upper control limit. Typical values are 0.5
+// for k and 4 or 5 for h. The standard deviation is estimated from the mean
+// moving range. ErrEmptyInput is returned for fewer than two values.
+func CUSUMChart(x []float64, k, h float64) (upper, lower ControlChart, err error) {
+	sigma, err := sigmaWithin(x, 1)
+	if err != nil {
+		return upper, lower, err
+	}
+	mean := Mean(x, nil)
+	cp := make([]float64, len(x))
+	cm := make([]float64, len(x))
+	var sp, sm float64
+	for i := 0; i < len(x); i++ {
+		sp = math.Max(0, sp+x[i]-mean-k*sigma)
+		sm = math.Max(0, sm+mean-k*sigma-x[i])
+		cp[i], cm[i] = sp, sm
+	}
+	upper = newControlChart(cp, 0, 0, h*sigma)
+	lower = newControlChart(cm, 0, 0, h*sigma)
+	return upper, lower, nil
+}
+
+// newControlChart returns a chart of values with constant control limits.
+func newControlChart(values []float64, center, lower, upper float64) ControlChart {
+	c := ControlChart{
+		Values: values,
+		Center: center,
+		Lower:  make([]float64, len(values)),
+		Upper:  make([]float64, len(values)),
+	}
+	for i := 0; i < len(values); i++ {
+		c.Lower[i], c.Upper[i] = lower, upper
+	}
+	c.OutOfControl = outOfControl(c)
+	return c
+}
+
+func outOfControl(c ControlChart) []int {
+	var idx []int
+	for i := 0; i < len(c.Values); i++ {
+		if c.Values[i] < c.Lower[i] || c.Values[i] > c.Upper[i] {
+			idx = append(idx, i)
+		}
+	}
+	return idx
+}
@@ -0,0 +1,88 @@
+package gostat
+
+import (
+	"testing"
+)
+
+func TestXBarRChart(t *testing.T) {
+	xbar, r, err := XBarRChart(testProcess, 3)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	compareArrays([]float64{10., 10.0333, 10.0667, 9.9333}, xbar.Values, t)
+	compareArrays([]float64{0.2, 0.4, 0.4, 0.4}, r.Values, t)
+	if got, want := xbar.Center, 10.0083; !floatEquals(got, want) {
+		t.Errorf("Expected center=%f, got=%f", want, got)
+	}
+	if got, want := xbar.Lower[0], 9.6503; !floatEquals(got, want) {
+		t.Errorf("Expected lower limit=%f, got=%f", want, got)
+	}
+	if got, want := xbar.Upper[3], 10.3664; !floatEquals(got, want) {
+		t.Errorf("Expected upper limit=%f, got=%f", want, got)
+	}
+	if got, want := r.Upper[0], 0.9009; !floatEquals(got, want) {
+		t.Errorf("Expected range upper limit=%f, got=%f", want, got)
+	}
+	if len(xbar.OutOfControl) != 0 || len(r.OutOfControl) != 0 {
+		t.Errorf("Expected process in control, got=%v and %v", xbar.OutOfControl, r.OutOfControl)
+	}
+	if _, _, err := XBarRChart(testProcess, 1); err != ErrInvalidParameter {
+		t.Errorf("Expected error=%v, got=%v", ErrInvalidParameter, err)
+	}
+}
+
+func TestIndividualsChart(t *testing.T) {
+	x := append(append([]float64{}, testProcess...), 12.)
+	ind, mr, err := IndividualsChart(testProcess)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if got, want := ind.Lower[0], 9.3314; !floatEquals(got, want) {
+		t.Errorf("Expected lower limit=%f, got=%f", want, got)
+	}
+	if got, want := ind.Upper[0], 10.6853; !floatEquals(got, want) {
+		t.Errorf("Expected upper limit=%f, got=%f", want, got)
+	}
+	if got, want := mr.Upper[0], 0.8316; !floatEquals(got, want) {
+		t.Errorf("Expected moving range upper limit=%f, got=%f", want, got)
+	}
+	ind, mr, _ = IndividualsChart(x)
+	compareIndices([]int{12}, ind.OutOfControl, t)
+	compareIndices([]int{11}, mr.OutOfControl, t)
+}
+
+func TestEWMAChart(t *testing.T) {
+	x := append(append([]float64{}, testProcess...), 10.6, 10.6, 10.7, 10.6, 10.6, 10.7, 10.6, 10.6)
+	c, err := EWMAChart(x, 0.2, 3.)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if c.Upper[0]-c.Center >= c.Upper[len(x)-1]-c.Center {
+		t.Errorf("Expected limits to widen, got=%f and %f", c.Upper[0], c.Upper[len(x)-1])
+	}
+	if n := len(c.OutOfControl); n == 0 || c.OutOfControl[n-1] != len(x)-1 || c.Values[len(x)-1] < c.Center {
+		t.Errorf("Expected upward shift to be detected, got=%v", c.OutOfControl)
+	}
+	if _, err := EWMAChart(x, 0., 3.); err != ErrInvalidParameter {
+		t.Errorf("Expected error=%v, got=%v", ErrInvalidParameter, err)
+	}
+}
+
+func TestCUSUMChart(t *testing.T) {
+	x := append(append([]float64{}, testProcess...), 10.2, 10.3, 10.3, 10.3, 10.3, 10.3)
+	upper, lower, err := CUSUMChart(x, 0.5, 4.)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(upper.OutOfControl) == 0 || upper.OutOfControl[0] < len(testProcess) {
+		t.Errorf("Expected upward shift to be detected, got=%v", upper.OutOfControl)
+	}
+	if len(lower.OutOfControl) != 0 {
+		t.Errorf("Expected no downward shift, got=%v", lower.OutOfControl)
+	}
+	for i := 0; i < len(x); i++ {
+		if upper.Values[i] < 0 || lower.Values[i] < 0 {
+			t.Errorf("Expected non-negative sums at index %d, got=%f and %f", i, upper.Values[i], lower.Values[i])
+		}
+	}
+}
@@ -0,0 +1,61 @@
+package gostat
+
+import (
+	"math"
+)
+
+// CrossSection returns the statistic fn of the values of the aligned series
+// at each observation, where series[j][i] is the i-th observation of the
+// j-th series, for example a panel of returns of the constituents of an
+// index, so that the i-th value summarizes all the series at time i. NaN
+// values, such as those of series not yet or no longer observed, are left
+// out of each cross-section, and the statistic is NaN where no value is
+// left. fn must not retain the slice it receives.
+func CrossSection(series [][]float64, fn func(values []float64) float64) []float64 {
+	n := checkSeries(series)
+	rets := make([]float64, n)
+	values := make([]float64, 0, len(series))
+	for i := 0; i < n; i++ {
+		values = values[:0]
+		for j := 0; j < len(series); j++ {
+			if !math.IsNaN(series[j][i]) {
+				values = append(values, series[j][i])
+			}
+		}
+		if len(values) == 0 {
+			rets[i] = math.NaN()
+			continue
+		}
+		rets[i] = fn(values)
+	}
+	return rets
+}
+
+// CrossSectionMean returns the mean of the aligned series at each
+// observation, see CrossSection.
+func CrossSectionMean(series [][]float64) []float64 {
+	return CrossSection(series, func(values []float64) float64 {
+		return Mean(values, nil)
+	})
+}
+
+// CrossSectionMedian returns the median of the aligned series at each
+// observation, see CrossSection.
+func CrossSectionMedian(series [][]float64) []float64 {
+	return CrossSection(series, Median)
+}
+
+// CrossSectionMAD returns the MAD of the aligned series at each
+// observation, a robust measure of their cross-sectional dispersion, see
+// CrossSection.
+func CrossSectionMAD(series [][]float64) []float64 {
+	return CrossSection(series, MAD)
+}
+
+// CrossSectionQuantile returns the p-quantile of the aligned series at each
+// observation estimated with the given method, see CrossSection.
+func CrossSectionQuantile(series [][]float64, p float64, method QuantileMethod) []float64 {
+	return CrossSection(series, func(values []float64) float64 {
+		return Quantile(values, p, method)
+	})
+}
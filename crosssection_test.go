@@ -0,0 +1,29 @@
+package gostat
+
+import (
+	"math"
+	"testing"
+)
+
+func TestCrossSection(t *testing.T) {
+	nan := math.NaN()
+	series := [][]float64{
+		{1., 2., nan, 4.},
+		{3., 2., nan, 8.},
+		{8., 5., nan, nan},
+	}
+	compareArrays([]float64{4., 3., nan, 6.}, CrossSectionMean(series), t)
+	compareArrays([]float64{3., 2., nan, 6.}, CrossSectionMedian(series), t)
+	compareArrays([]float64{2.9652, 0., nan, 2.9652}, CrossSectionMAD(series), t)
+	compareArrays([]float64{5.5, 3.5, nan, 7.}, CrossSectionQuantile(series, 0.75, QuantileLinear), t)
+	breadth := CrossSection(series, func(values []float64) float64 {
+		var up float64
+		for _, v := range values {
+			if v > 2 {
+				up++
+			}
+		}
+		return up / float64(len(values))
+	})
+	compareArrays([]float64{0.6667, 0.3333, nan, 1.}, breadth, t)
+}
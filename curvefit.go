@@ -0,0 +1,147 @@
+package gostat
+
+import (
+	"math"
+	"sort"
+)
+
+// CurveFit is a two-parameter curve fitted to observations, y = A*exp(B*x)
+// for FitExponential and y = A*x^B for FitPowerLaw.
+type CurveFit struct {
+	A, B float64
+	// R2 is the coefficient of determination of the linear fit in log
+	// space.
+	R2 float64
+}
+
+// FitExponential fits the exponential growth curve y = A*exp(B*x) by least
+// squares of log(y) on x, so that B is the continuously compounded growth
+// rate per unit of x. All values of y must be positive.
+func FitExponential(x, y []float64) (CurveFit, error) {
+	logY, err := logPositive(y)
+	if err != nil {
+		return CurveFit{}, err
+	}
+	return fitLogLinear(x, logY)
+}
+
+// FitPowerLaw fits the power law y = A*x^B by least squares of log(y) on
+// log(x). All values of x and y must be positive. See FitPowerLawTail for
+// fitting the tail of a distribution, for which the least squares fit of a
+// histogram or an empirical distribution is biased.
+func FitPowerLaw(x, y []float64) (CurveFit, error) {
+	logX, err := logPositive(x)
+	if err != nil {
+		return CurveFit{}, err
+	}
+	logY, err := logPositive(y)
+	if err != nil {
+		return CurveFit{}, err
+	}
+	return fitLogLinear(logX, logY)
+}
+
+func fitLogLinear(x, logY []float64) (CurveFit, error) {
+	var fit CurveFit
+	if err := checkRegression(logY, [][]float64{x}); err != nil {
+		return fit, err
+	}
+	coef, err := leastSquares(logY, [][]float64{x}, nil)
+	if err != nil {
+		return fit, err
+	}
+	mean := Mean(logY, nil)
+	var rss, tss neumaierSum
+	for i := 0; i < len(logY); i++ {
+		r := logY[i] - coef[0] - coef[1]*x[i]
+		rss.add(r * r)
+		tss.add((logY[i] - mean) * (logY[i] - mean))
+	}
+	fit.A, fit.B = math.Exp(coef[0]), coef[1]
+	fit.R2 = 1 - rss.total()/tss.total()
+	return fit, nil
+}
+
+// logPositive returns the logarithms of x, or an error unless all values of
+// x are positive.
+func logPositive(x []float64) ([]float64, error) {
+	rets := make([]float64, len(x))
+	for i := 0; i < len(x); i++ {
+		if !(x[i] > 0) {
+			return nil, ErrInvalidParameter
+		}
+		rets[i] = math.Log(x[i])
+	}
+	return rets, nil
+}
+
+// PowerLawTail is a continuous power law fitted to the tail of a sample,
+// with density proportional to x^-Alpha for x >= XMin.
+type PowerLawTail struct {
+	Alpha, XMin float64
+	// N is the number of observations in the tail.
+	N int
+	// KS is the Kolmogorov-Smirnov distance between the empirical and the
+	// fitted distribution of the tail.
+	KS float64
+}
+
+// FitPowerLawTail fits a continuous power law to the values of x at or above
+// xmin by maximum likelihood, Alpha = 1 + n / sum(log(x/xmin)), for example
+// to the absolute returns of an asset to estimate its tail index Alpha-1.
+// When xmin is not positive it is selected among the values of x as the one
+// minimizing the KS distance of the fit, as proposed by Clauset, Shalizi and
+// Newman, among those leaving at least minTail observations in the tail.
+// NaN values and values that are not positive are ignored.
+func FitPowerLawTail(x []float64, xmin float64, minTail int) (PowerLawTail, error) {
+	var fit PowerLawTail
+	var v []float64
+	for i := 0; i < len(x); i++ {
+		if x[i] > 0 && !math.IsInf(x[i], 1) {
+			v = append(v, x[i])
+		}
+	}
+	sort.Float64s(v)
+	minTail = maxInt(minTail, 2)
+	if len(v) < minTail {
+		return fit, ErrEmptyInput
+	}
+
+	if xmin > 0 {
+		start := sort.SearchFloat64s(v, xmin)
+		if len(v)-start < 2 {
+			return fit, ErrEmptyInput
+		}
+		return powerLawTail(v[start:], xmin), nil
+	}
+	fit.KS = math.Inf(1)
+	for start := 0; start <= len(v)-minTail; start++ {
+		if start > 0 && v[start] == v[start-1] {
+			continue
+		}
+		if f := powerLawTail(v[start:], v[start]); f.KS < fit.KS {
+			fit = f
+		}
+	}
+	if math.IsInf(fit.Alpha, 1) {
+		return fit, ErrInvalidParameter
+	}
+	return fit, nil
+}
+
+// powerLawTail returns the maximum likelihood fit of a power law to the
+// sorted tail observations at or above xmin.
+func powerLawTail(tail []float64, xmin float64) PowerLawTail {
+	var sum neumaierSum
+	for i := 0; i < len(tail); i++ {
+		sum.add(math.Log(tail[i] / xmin))
+	}
+	n := float64(len(tail))
+	alpha := 1 + n/sum.total()
+	var ks float64
+	for i := 0; i < len(tail); i++ {
+		cdf := 1 - math.Pow(tail[i]/xmin, 1-alpha)
+		ks = math.Max(ks, math.Max(math.Abs(float64(i+1)/n-cdf), math.Abs(float64(i)/n-cdf)))
+	}
+	return PowerLawTail{Alpha: alpha, XMin: xmin, N: len(tail), KS: ks}
+}
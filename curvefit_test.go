@@ -0,0 +1,70 @@
+package gostat
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func TestFitExponential(t *testing.T) {
+	x := []float64{0., 1., 2., 3., 4.}
+	y := make([]float64, len(x))
+	for i := 0; i < len(x); i++ {
+		y[i] = 2 * math.Exp(0.3*x[i])
+	}
+	fit, err := FitExponential(x, y)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	compareArrays([]float64{2., 0.3, 1.}, []float64{fit.A, fit.B, fit.R2}, t)
+}
+
+func TestFitPowerLaw(t *testing.T) {
+	x := []float64{1., 2., 4., 8., 16.}
+	y := []float64{3., 1.1, 0.4, 0.12, 0.05}
+	fit, err := FitPowerLaw(x, y)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	compareArrays([]float64{3.0440, -1.5010, 0.9983}, []float64{fit.A, fit.B, fit.R2}, t)
+	if _, err := FitPowerLaw([]float64{0., 1.}, []float64{1., 2.}); err != ErrInvalidParameter {
+		t.Errorf("Expected error=%v, got=%v", ErrInvalidParameter, err)
+	}
+	if _, err := FitExponential([]float64{1., 2.}, []float64{1.}); err != ErrLengthMismatch {
+		t.Errorf("Expected error=%v, got=%v", ErrLengthMismatch, err)
+	}
+}
+
+func TestFitPowerLawTail(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	x := make([]float64, 2000)
+	for i := 0; i < len(x); i++ {
+		if i%2 == 0 {
+			// a uniform body below the power law tail from 1 on
+			x[i] = rnd.Float64()
+		} else {
+			x[i] = math.Pow(1-rnd.Float64(), -1/1.5)
+		}
+	}
+	fit, err := FitPowerLawTail(x, 0, 50)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if fit.Alpha < 2.4 || fit.Alpha > 2.6 {
+		t.Errorf("Expected alpha near 2.5, got=%f", fit.Alpha)
+	}
+	if fit.XMin < 0.9 || fit.XMin > 1.2 {
+		t.Errorf("Expected xmin near 1, got=%f", fit.XMin)
+	}
+
+	fixed, err := FitPowerLawTail([]float64{1., 2., 4., 0.5}, 1, 2)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if got, want := fixed.Alpha, 1+3/(3*math.Log(2)); !floatEquals(got, want) || fixed.N != 3 {
+		t.Errorf("Expected alpha=%f over 3 values, got=%f over %d", want, got, fixed.N)
+	}
+	if _, err := FitPowerLawTail([]float64{1.}, 0, 2); err != ErrEmptyInput {
+		t.Errorf("Expected error=%v, got=%v", ErrEmptyInput, err)
+	}
+}
@@ -0,0 +1,150 @@
+package gostat
+
+import (
+	"math"
+	"sort"
+)
+
+// Summary holds the descriptive statistics of a sample returned by Describe.
+type Summary struct {
+	// Count is the number of observations.
+	Count int
+	// Mean and StdDev are the mean and the unbiased standard deviation.
+	Mean, StdDev float64
+	// Min and Max are the lowest and the highest observation.
+	Min, Max float64
+	// Median and MAD are the median and the median absolute deviation.
+	Median, MAD float64
+	// Skewness is the sample skewness and Kurtosis the sample excess
+	// kurtosis, both corrected for bias the same way as stat.Skew and
+	// stat.ExKurtosis.
+	Skewness, Kurtosis float64
+	// P5, P25, P75 and P95 are the 5th, 25th, 75th and 95th percentiles.
+	P5, P25, P75, P95 float64
+}
+
+// Describe returns the descriptive statistics of x in a single Summary,
+// sorting x once for the order statistics and scanning it twice for the
+// moments. Weights may be nil for equal weights, otherwise the median, MAD
+// and percentiles are those of WeightedMedian, WeightedMAD and
+// WeightedQuantile, and observations with zero weight only contribute to
+// Count. Without weights the percentiles are interpolated linearly, see
+// Quantile. All statistics except Count are NaN when x is empty or contains
+// NaN, and the moments are NaN when there are too few observations to
+// define them.
+func Describe(x, weights []float64) Summary {
+	if weights != nil && len(weights) != len(x) {
+		panic("gostat: slice length mismatch")
+	}
+	nan := math.NaN()
+	s := Summary{
+		Count: len(x), Mean: nan, StdDev: nan, Min: nan, Max: nan,
+		Median: nan, MAD: nan, Skewness: nan, Kurtosis: nan,
+		P5: nan, P25: nan, P75: nan, P95: nan,
+	}
+	if hasNaN(x) {
+		return s
+	}
+
+	var v, w []float64
+	if weights == nil {
+		v = append([]float64{}, x...)
+		sort.Float64s(v)
+	} else {
+		for i := 0; i < len(x); i++ {
+			if weights[i] < 0 {
+				return s
+			}
+			if weights[i] > 0 {
+				v = append(v, x[i])
+				w = append(w, weights[i])
+			}
+		}
+		v, w = sortWeighted(v, w)
+	}
+	if len(v) == 0 {
+		return s
+	}
+	s.Mean, s.StdDev, s.Skewness, s.Kurtosis = moments(v, w)
+	s.Min, s.Max = v[0], v[len(v)-1]
+
+	quantile := func(p float64) float64 {
+		return quantileSorted(v, p, QuantileLinear)
+	}
+	if w != nil {
+		total := Sum(w)
+		quantile = func(p float64) float64 {
+			return weightedQuantileSorted(v, w, total, p)
+		}
+	}
+	s.Median = quantile(0.5)
+	s.P5, s.P25, s.P75, s.P95 = quantile(0.05), quantile(0.25), quantile(0.75), quantile(0.95)
+
+	dev := make([]float64, len(v))
+	for i := 0; i < len(v); i++ {
+		dev[i] = math.Abs(v[i] - s.Median)
+	}
+	if w == nil {
+		sort.Float64s(dev)
+		s.MAD = 1.4826 * quantileSorted(dev, 0.5, QuantileLinear)
+	} else {
+		dev, devW := sortWeighted(dev, w)
+		s.MAD = 1.4826 * weightedQuantileSorted(dev, devW, Sum(devW), 0.5)
+	}
+	return s
+}
+
+// RollingDescribe returns the descriptive statistics of each sliding window
+// of length k across neighboring elements of x, selected by opts the same
+// way as by MovApply.
+func RollingDescribe(x []float64, k int, opts WindowOpts) []Summary {
+	var rets []Summary
+	movEach(x, k, opts, func(n int) {
+		rets = make([]Summary, 0, n)
+	}, func(window, weights []float64) {
+		rets = append(rets, Describe(window, weights))
+	}, func() {
+		rets = append(rets, Describe(nil, nil))
+	})
+	return rets
+}
+
+// moments returns the weighted mean, unbiased standard deviation, skewness
+// and excess kurtosis of x in two passes, matching the definitions of
+// stat.MeanStdDev, stat.Skew and stat.ExKurtosis.
+func moments(x, weights []float64) (mean, std, skew, kurt float64) {
+	n := float64(len(x))
+	if weights != nil {
+		n = Sum(weights)
+	}
+	mean = Mean(x, weights)
+
+	var comp, m2, m3, m4 float64
+	for i := 0; i < len(x); i++ {
+		wi := 1.
+		if weights != nil {
+			wi = weights[i]
+		}
+		d := x[i] - mean
+		comp += wi * d
+		m2 += wi * d * d
+		m3 += wi * d * d * d
+		m4 += wi * d * d * d * d
+	}
+	std = math.Sqrt((m2 - comp*comp/n) / (n - 1))
+
+	skew = m3 / (std * std * std) * (n / (n - 1)) / (n - 2)
+	mul := ((n + 1) / (n - 1)) * (n / (n - 2)) / (n - 3)
+	offset := 3 * ((n - 1) / (n - 2)) * ((n - 1) / (n - 3))
+	kurt = m4/(std*std*std*std)*mul - offset
+	if n < 2 {
+		std = math.NaN()
+	}
+	if n < 3 {
+		skew = math.NaN()
+	}
+	if n < 4 {
+		kurt = math.NaN()
+	}
+	return mean, std, skew, kurt
+}
@@ -0,0 +1,92 @@
+package gostat
+
+import (
+	"github.com/gonum/stat"
+	"math"
+	"testing"
+)
+
+func TestDescribe(t *testing.T) {
+	x := []float64{2., 6., 6., 12., 17., 25., 32., 1., 9., 14.}
+	s := Describe(x, nil)
+	if s.Count != len(x) {
+		t.Errorf("Expected count=%d, got=%d", len(x), s.Count)
+	}
+	checks := []struct {
+		name      string
+		got, want float64
+	}{
+		{"mean", s.Mean, stat.Mean(x, nil)},
+		{"std dev", s.StdDev, stat.StdDev(x, nil)},
+		{"min", s.Min, 1.},
+		{"max", s.Max, 32.},
+		{"median", s.Median, Median(x)},
+		{"MAD", s.MAD, MAD(x)},
+		{"skewness", s.Skewness, stat.Skew(x, nil)},
+		{"kurtosis", s.Kurtosis, stat.ExKurtosis(x, nil)},
+		{"P5", s.P5, Quantile(x, 0.05, QuantileLinear)},
+		{"P25", s.P25, Quantile(x, 0.25, QuantileLinear)},
+		{"P75", s.P75, Quantile(x, 0.75, QuantileLinear)},
+		{"P95", s.P95, Quantile(x, 0.95, QuantileLinear)},
+	}
+	for _, c := range checks {
+		if !floatEquals(c.got, c.want) {
+			t.Errorf("Expected %s=%f, got=%f", c.name, c.want, c.got)
+		}
+	}
+}
+
+func TestDescribe_Weighted(t *testing.T) {
+	x := []float64{3., 1., 4., 1., 5., 9., 2., 6.}
+	w := []float64{1., 2., 1., 0.5, 3., 1., 2., 1.5}
+	s := Describe(x, w)
+	checks := []struct {
+		name      string
+		got, want float64
+	}{
+		{"mean", s.Mean, stat.Mean(x, w)},
+		{"std dev", s.StdDev, stat.StdDev(x, w)},
+		{"median", s.Median, WeightedMedian(x, w)},
+		{"MAD", s.MAD, WeightedMAD(x, w)},
+		{"skewness", s.Skewness, stat.Skew(x, w)},
+		{"kurtosis", s.Kurtosis, stat.ExKurtosis(x, w)},
+		{"P25", s.P25, WeightedQuantile(x, w, 0.25)},
+		{"P95", s.P95, WeightedQuantile(x, w, 0.95)},
+	}
+	for _, c := range checks {
+		if !floatEquals(c.got, c.want) {
+			t.Errorf("Expected %s=%f, got=%f", c.name, c.want, c.got)
+		}
+	}
+}
+
+func TestDescribe_Degenerate(t *testing.T) {
+	s := Describe(nil, nil)
+	if s.Count != 0 || !math.IsNaN(s.Mean) || !math.IsNaN(s.Median) {
+		t.Errorf("Expected empty summary, got=%+v", s)
+	}
+	s = Describe([]float64{1., math.NaN(), 3.}, nil)
+	if s.Count != 3 || !math.IsNaN(s.Mean) || !math.IsNaN(s.Max) {
+		t.Errorf("Expected NaN summary, got=%+v", s)
+	}
+	s = Describe([]float64{4., 2.}, nil)
+	if !floatEquals(s.Mean, 3.) || !math.IsNaN(s.Skewness) || !math.IsNaN(s.Kurtosis) {
+		t.Errorf("Expected mean=3 and NaN higher moments, got=%+v", s)
+	}
+}
+
+func TestRollingDescribe(t *testing.T) {
+	x := []float64{4., 8., 6., -1., -2., -3., -1., 3., 4., 5.}
+	opts := WindowOpts{Trailing: true}
+	summaries := RollingDescribe(x, 3, opts)
+	if len(summaries) != len(x) {
+		t.Fatalf("Expected %d summaries, got=%d", len(x), len(summaries))
+	}
+	means := make([]float64, len(summaries))
+	maxs := make([]float64, len(summaries))
+	for i, s := range summaries {
+		means[i], maxs[i] = s.Mean, s.Max
+	}
+	compareArrays(MovMean(x, 3, opts), means, t)
+	compareArrays(MovMax(x, 3, opts), maxs, t)
+}
@@ -0,0 +1,125 @@
+package gostat
+
+import (
+	"math"
+	"sort"
+)
+
+// Wasserstein returns the Wasserstein-1 or earth mover's distance between
+// the empirical distributions of the samples x and y, the area between their
+// distribution functions, which is the smallest average distance the values
+// of one sample must be moved to turn it into the other. Unlike the
+// Kolmogorov-Smirnov statistic it is in the units of the samples and grows
+// with the size of the shift between them. NaN values are ignored, and the
+// distance is NaN when either sample has no other values.
+func Wasserstein(x, y []float64) float64 {
+	a, b := sortedFinite(x), sortedFinite(y)
+	if len(a) == 0 || len(b) == 0 {
+		return math.NaN()
+	}
+	na, nb := float64(len(a)), float64(len(b))
+	var dist neumaierSum
+	var i, j int
+	last := math.Min(a[0], b[0])
+	for i < len(a) || j < len(b) {
+		var v float64
+		if j == len(b) || (i < len(a) && a[i] <= b[j]) {
+			v = a[i]
+		} else {
+			v = b[j]
+		}
+		dist.add(math.Abs(float64(i)/na-float64(j)/nb) * (v - last))
+		for i < len(a) && a[i] == v {
+			i++
+		}
+		for j < len(b) && b[j] == v {
+			j++
+		}
+		last = v
+	}
+	return dist.total()
+}
+
+// TotalVariation returns the total variation distance between the
+// distributions of the samples x and y, half the sum of the absolute
+// differences between the fractions of each sample falling into each of
+// bins equal width bins spanning both samples. It is between 0 for
+// identical histograms and 1 for samples with no bin in common. NaN values
+// are ignored, and the distance is NaN when either sample has no other
+// values or bins is smaller than one.
+func TotalVariation(x, y []float64, bins int) float64 {
+	p, q := histograms(x, y, bins)
+	if p == nil {
+		return math.NaN()
+	}
+	var sum float64
+	for k := 0; k < bins; k++ {
+		sum += math.Abs(p[k] - q[k])
+	}
+	return sum / 2
+}
+
+// JensenShannon returns the Jensen-Shannon divergence between the
+// distributions of the samples x and y, binned as by TotalVariation, the
+// average Kullback-Leibler divergence of each histogram from their mixture.
+// It is measured in bits, so that it is between 0 for identical histograms
+// and 1 for samples with no bin in common, and unlike the Kullback-Leibler
+// divergence it is symmetric and finite for empty bins.
+func JensenShannon(x, y []float64, bins int) float64 {
+	p, q := histograms(x, y, bins)
+	if p == nil {
+		return math.NaN()
+	}
+	var div float64
+	for k := 0; k < bins; k++ {
+		m := (p[k] + q[k]) / 2
+		if p[k] > 0 {
+			div += p[k] * math.Log2(p[k]/m) / 2
+		}
+		if q[k] > 0 {
+			div += q[k] * math.Log2(q[k]/m) / 2
+		}
+	}
+	return div
+}
+
+// histograms returns the fractions of the finite values of x and y in each
+// of bins equal width bins spanning both samples, or nil if either sample
+// has no finite values or bins is smaller than one.
+func histograms(x, y []float64, bins int) ([]float64, []float64) {
+	a, b := sortedFinite(x), sortedFinite(y)
+	if len(a) == 0 || len(b) == 0 || bins < 1 {
+		return nil, nil
+	}
+	lo := math.Min(a[0], b[0])
+	hi := math.Max(a[len(a)-1], b[len(b)-1])
+	edges := make([]float64, bins-1)
+	for k := 1; k < bins; k++ {
+		edges[k-1] = lo + (hi-lo)*float64(k)/float64(bins)
+	}
+	return binFractions(a, edges, false), binFractions(b, edges, false)
+}
+
+// binFractions returns the fractions of the values of x falling into each of
+// the bins separated by ascending edges, where the k-th bin holds the values
+// at or above edge k-1 and below edge k, or above edge k-1 and at most edge
+// k when right is set.
+func binFractions(x, edges []float64, right bool) []float64 {
+	p := make([]float64, len(edges)+1)
+	for i := 0; i < len(x); i++ {
+		p[sort.Search(len(edges), func(k int) bool {
+			return edges[k] > x[i] || (right && edges[k] == x[i])
+		})]++
+	}
+	for k := 0; k < len(p); k++ {
+		p[k] /= float64(len(x))
+	}
+	return p
+}
+
+// sortedFinite returns a sorted copy of the finite values of x.
+func sortedFinite(x []float64) []float64 {
+	v := filterNaNs(x)
+	sort.Float64s(v)
+	return v
+}
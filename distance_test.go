@@ -0,0 +1,51 @@
+package gostat
+
+import (
+	"math"
+	"testing"
+)
+
+func TestWasserstein(t *testing.T) {
+	x := []float64{1., 2., 3.}
+	if got, want := Wasserstein(x, []float64{3., 4., 5.}), 2.; !floatEquals(got, want) {
+		t.Errorf("Expected distance=%f, got=%f", want, got)
+	}
+	if got, want := Wasserstein(x, []float64{math.NaN(), 1., 3.}), 0.3333; !floatEquals(got, want) {
+		t.Errorf("Expected distance=%f, got=%f", want, got)
+	}
+	if got, want := Wasserstein(x, x), 0.; !floatEquals(got, want) {
+		t.Errorf("Expected distance=%f, got=%f", want, got)
+	}
+	if got := Wasserstein(x, nil); !math.IsNaN(got) {
+		t.Errorf("Expected distance=NaN, got=%f", got)
+	}
+}
+
+func TestTotalVariation(t *testing.T) {
+	x := []float64{0., 1., 2., 3.}
+	y := []float64{2., 3., 3., 4.}
+	// bins [0, 1), [1, 2), [2, 3) and [3, 4]
+	if got, want := TotalVariation(x, y, 4), 0.5; !floatEquals(got, want) {
+		t.Errorf("Expected distance=%f, got=%f", want, got)
+	}
+	if got, want := TotalVariation(x, []float64{10., 11.}, 2), 1.; !floatEquals(got, want) {
+		t.Errorf("Expected distance=%f, got=%f", want, got)
+	}
+	if got := TotalVariation(x, y, 0); !math.IsNaN(got) {
+		t.Errorf("Expected distance=NaN, got=%f", got)
+	}
+}
+
+func TestJensenShannon(t *testing.T) {
+	x := []float64{0., 1., 2., 3.}
+	y := []float64{2., 3., 3., 4.}
+	if got, want := JensenShannon(x, y, 4), 0.3444; !floatEquals(got, want) {
+		t.Errorf("Expected divergence=%f, got=%f", want, got)
+	}
+	if got, want := JensenShannon(x, []float64{10., 11.}, 2), 1.; !floatEquals(got, want) {
+		t.Errorf("Expected divergence=%f, got=%f", want, got)
+	}
+	if got, want := JensenShannon(x, x, 3), 0.; !floatEquals(got, want) {
+		t.Errorf("Expected divergence=%f, got=%f", want, got)
+	}
+}
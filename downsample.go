@@ -0,0 +1,66 @@
+package gostat
+
+import (
+	"math"
+)
+
+// LTTB downsamples the series y observed at x, or at the observation index
+// when x is nil, to n points by the Largest-Triangle-Three-Buckets algorithm
+// of Steinarsson, which keeps the visual shape of the series, its peaks and
+// troughs included, far better than taking every k-th point or averaging,
+// for example to chart a long price history. It returns the ascending
+// indices of the selected points, which always include the first and the
+// last point, or all indices when n is less than 3 or at least len(y).
+func LTTB(x, y []float64, n int) []int {
+	if x != nil {
+		checkSeries([][]float64{x, y})
+	}
+	at := func(i int) float64 {
+		if x == nil {
+			return float64(i)
+		}
+		return x[i]
+	}
+	if n < 3 || n >= len(y) {
+		idx := make([]int, len(y))
+		for i := 0; i < len(y); i++ {
+			idx[i] = i
+		}
+		return idx
+	}
+
+	// the points between the first and the last are split into n-2 buckets,
+	// and the point of each bucket forming the largest triangle with the
+	// point selected in the previous bucket and the average of the next
+	// bucket is selected
+	every := float64(len(y)-2) / float64(n-2)
+	idx := make([]int, 0, n)
+	idx = append(idx, 0)
+	a := 0
+	for b := 0; b < n-2; b++ {
+		start := int(float64(b)*every) + 1
+		end := int(float64(b+1)*every) + 1
+		nextEnd := minInt(int(float64(b+2)*every)+1, len(y))
+		if b == n-3 {
+			nextEnd = len(y)
+		}
+		var avgX, avgY float64
+		for j := end; j < nextEnd; j++ {
+			avgX += at(j)
+			avgY += y[j]
+		}
+		avgX /= float64(nextEnd - end)
+		avgY /= float64(nextEnd - end)
+
+		best, bestArea := start, -1.
+		for j := start; j < end; j++ {
+			area := math.Abs((at(a)-avgX)*(y[j]-y[a]) - (at(a)-at(j))*(avgY-y[a]))
+			if area > bestArea {
+				best, bestArea = j, area
+			}
+		}
+		idx = append(idx, best)
+		a = best
+	}
+	return append(idx, len(y)-1)
+}
@@ -0,0 +1,38 @@
+package gostat
+
+import (
+	"math"
+	"testing"
+)
+
+func TestLTTB(t *testing.T) {
+	y := []float64{0., 1., 0., 0., 10., 0., 0., -5., 0., 0.}
+	compareIndices([]int{0, 4, 7, 9}, LTTB(nil, y, 4), t)
+	compareIndices([]int{0, 1, 2}, LTTB(nil, []float64{1., 2., 3.}, 5), t)
+	compareIndices([]int{0, 1, 2}, LTTB(nil, []float64{1., 2., 3.}, 2), t)
+}
+
+func TestLTTB_Sine(t *testing.T) {
+	n := 1000
+	x := make([]float64, n)
+	y := make([]float64, n)
+	for i := 0; i < n; i++ {
+		x[i] = float64(i) / 10
+		y[i] = math.Sin(x[i])
+	}
+	idx := LTTB(x, y, 100)
+	if len(idx) != 100 || idx[0] != 0 || idx[99] != n-1 {
+		t.Fatalf("Expected 100 points from first to last, got=%d", len(idx))
+	}
+	var maxY, minY float64
+	for i := 1; i < len(idx); i++ {
+		if idx[i] <= idx[i-1] {
+			t.Fatalf("Expected ascending indices, got=%v", idx)
+		}
+		maxY = math.Max(maxY, y[idx[i]])
+		minY = math.Min(minY, y[idx[i]])
+	}
+	if maxY < 0.99 || minY > -0.99 {
+		t.Errorf("Expected peaks and troughs kept, got max=%f min=%f", maxY, minY)
+	}
+}
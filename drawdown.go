@@ -0,0 +1,45 @@
+package gostat
+
+// MovMaxDrawdown returns moving maximum drawdown, a slice of the maximum
+// drawdowns within trailing windows of length k over prices. The drawdown
+// is the decline from a running peak expressed as a fraction of the peak,
+// so a fall from 100 to 75 is a drawdown of 0.25.
+func MovMaxDrawdown(prices []float64, k int) []float64 {
+	return MovApply(prices, k, WindowOpts{Trailing: true}, func(window, _ []float64) float64 {
+		depth, _, _ := MaxDrawdown(window)
+		return depth
+	})
+}
+
+// MaxDrawdown returns the largest decline from a running peak of prices as a
+// fraction of the peak, with the indices of the peak and the trough. The
+// depth and both indices are 0 when prices never fall below a running peak.
+func MaxDrawdown(prices []float64) (depth float64, peakIdx, troughIdx int) {
+	peak := 0
+	for i := 1; i < len(prices); i++ {
+		if prices[i] > prices[peak] {
+			peak = i
+			continue
+		}
+		if dd := (prices[peak] - prices[i]) / prices[peak]; dd > depth {
+			depth, peakIdx, troughIdx = dd, peak, i
+		}
+	}
+	return depth, peakIdx, troughIdx
+}
+
+// Drawdowns returns the drawdown series of prices, the decline of each price
+// from the running peak up to it as a fraction of the peak. The drawdown is
+// 0 at every new peak, and its maximum is the depth returned by MaxDrawdown.
+func Drawdowns(prices []float64) []float64 {
+	dd := make([]float64, len(prices))
+	var peak float64
+	for i := 0; i < len(prices); i++ {
+		if i == 0 || prices[i] > peak {
+			peak = prices[i]
+			continue
+		}
+		dd[i] = (peak - prices[i]) / peak
+	}
+	return dd
+}
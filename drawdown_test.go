@@ -0,0 +1,34 @@
+package gostat
+
+import (
+	"testing"
+)
+
+func TestMovMaxDrawdown(t *testing.T) {
+	prices := []float64{100., 110., 99., 105., 88., 120.}
+	dd := MovMaxDrawdown(prices, 3)
+	compareArrays([]float64{0., 0., 0.1, 0.1, 0.1619, 0.1619}, dd, t)
+}
+
+func TestMovMaxDrawdown_Rising(t *testing.T) {
+	prices := []float64{1., 2., 3., 4.}
+	dd := MovMaxDrawdown(prices, 2)
+	compareArrays([]float64{0., 0., 0., 0.}, dd, t)
+}
+
+func TestMaxDrawdown_Indices(t *testing.T) {
+	prices := []float64{100., 120., 90., 130., 100., 125.}
+	depth, peak, trough := MaxDrawdown(prices)
+	if got, want := depth, 0.25; !floatEquals(got, want) {
+		t.Errorf("Expected drawdown=%f, got=%f", want, got)
+	}
+	if peak != 1 || trough != 2 {
+		t.Errorf("Expected peak=1 and trough=2, got peak=%d and trough=%d", peak, trough)
+	}
+}
+
+func TestDrawdowns(t *testing.T) {
+	prices := []float64{100., 120., 90., 130., 100., 125.}
+	dd := Drawdowns(prices)
+	compareArrays([]float64{0., 0., 0.25, 0., 0.2308, 0.0385}, dd, t)
+}
@@ -0,0 +1,168 @@
+package gostat
+
+import (
+	"errors"
+	"math"
+)
+
+var (
+	// ErrEmptyInput is returned when a series has too few values to
+	// calculate a statistic, typically none.
+	ErrEmptyInput = errors.New("gostat: empty input")
+	// ErrNaNInput is returned when a series has no values other than NaN,
+	// or has any NaN value under the NaNError policy.
+	ErrNaNInput = errors.New("gostat: NaN input")
+	// ErrInvalidWindow is returned for a window length smaller than one.
+	ErrInvalidWindow = errors.New("gostat: invalid window length")
+	// ErrInvalidProbability is returned for a probability outside of the
+	// [0, 1] range.
+	ErrInvalidProbability = errors.New("gostat: probability out of range")
+	// ErrLengthMismatch is returned when slices expected to be aligned
+	// have different lengths.
+	ErrLengthMismatch = errors.New("gostat: slice length mismatch")
+	// ErrInvalidParameter is returned for a parameter outside of its valid
+	// range.
+	ErrInvalidParameter = errors.New("gostat: invalid parameter")
+	// ErrSingularMatrix is returned when a covariance or a design matrix
+	// cannot be inverted.
+	ErrSingularMatrix = errors.New("gostat: singular matrix")
+	// ErrNotConverged is returned when a numerical algorithm fails to
+	// converge.
+	ErrNotConverged = errors.New("gostat: algorithm did not converge")
+)
+
+// checkInput returns an error unless x has at least min values and a value
+// other than NaN.
+func checkInput(x []float64, min int) error {
+	if len(x) == 0 || len(x) < min {
+		return ErrEmptyInput
+	}
+	for i := 0; i < len(x); i++ {
+		if !math.IsNaN(x[i]) {
+			return nil
+		}
+	}
+	return ErrNaNInput
+}
+
+// checkPanel returns an error unless there is at least one series, all
+// series have the same length and at least min observations.
+func checkPanel(series [][]float64, min int) error {
+	if len(series) == 0 {
+		return ErrEmptyInput
+	}
+	for j := 1; j < len(series); j++ {
+		if len(series[j]) != len(series[0]) {
+			return ErrLengthMismatch
+		}
+	}
+	if len(series[0]) == 0 || len(series[0]) < min {
+		return ErrEmptyInput
+	}
+	return nil
+}
+
+// checkWeights returns an error unless weights is nil or has n values.
+func checkWeights(weights []float64, n int) error {
+	if weights != nil && len(weights) != n {
+		return ErrLengthMismatch
+	}
+	return nil
+}
+
+// checkWindow returns an error unless x can be split into windows of
+// length k with opts.
+func checkWindow(x []float64, k int, opts WindowOpts) error {
+	if k < 1 {
+		return ErrInvalidWindow
+	}
+	if err := checkInput(x, 1); err != nil {
+		return err
+	}
+	if opts.OmitNaNs && len(filterNaNs(x)) == 0 {
+		return ErrNaNInput
+	}
+	if opts.NaNPolicy == NaNError && hasNaN(x) {
+		return ErrNaNInput
+	}
+	return checkWeights(opts.Weights, k)
+}
+
+// MedianE is like Median, but returns an error for empty or NaN only input.
+func MedianE(x []float64) (float64, error) {
+	if err := checkInput(x, 1); err != nil {
+		return math.NaN(), err
+	}
+	return Median(x), nil
+}
+
+// MADE is like MAD, but returns an error for empty or NaN only input instead
+// of a negative MAD.
+func MADE(x []float64) (float64, error) {
+	if err := checkInput(x, 1); err != nil {
+		return math.NaN(), err
+	}
+	return MAD(x), nil
+}
+
+// QuantileE is like Quantile, but returns an error for empty or NaN only
+// input, or p outside of the [0, 1] range.
+func QuantileE(x []float64, p float64, method QuantileMethod) (float64, error) {
+	if err := checkInput(x, 1); err != nil {
+		return math.NaN(), err
+	}
+	if !(p >= 0 && p <= 1) {
+		return math.NaN(), ErrInvalidProbability
+	}
+	return Quantile(x, p, method), nil
+}
+
+// NormalizeE is like Normalize, but returns an error for empty or NaN only
+// input, or weights not matching the length of x.
+func NormalizeE(x, weights []float64) ([]float64, error) {
+	if err := checkInput(x, 1); err != nil {
+		return nil, err
+	}
+	if err := checkWeights(weights, len(x)); err != nil {
+		return nil, err
+	}
+	return Normalize(x, weights), nil
+}
+
+// VolatilityE is like Volatility, but returns an error when there are fewer
+// than two prices or only NaN prices.
+func VolatilityE(x []float64, periodicity float64) (float64, error) {
+	if err := checkInput(x, 2); err != nil {
+		return math.NaN(), err
+	}
+	return Volatility(x, periodicity), nil
+}
+
+// RollingWindowE is like RollingWindow, but returns an error for empty or
+// NaN only input, or a window length smaller than one.
+func RollingWindowE(x []float64, k int, omitNaNs, trailing, fullWnd bool) ([][]float64, error) {
+	if err := checkWindow(x, k, WindowOpts{OmitNaNs: omitNaNs}); err != nil {
+		return nil, err
+	}
+	return RollingWindow(x, k, omitNaNs, trailing, fullWnd), nil
+}
+
+// MovApplyE is like MovApply, but returns an error for empty or NaN only
+// input, a window length smaller than one, weights in opts not matching the
+// window length, or any NaN value with the NaNError policy.
+func MovApplyE(x []float64, k int, opts WindowOpts, fn func(window, weights []float64) float64) ([]float64, error) {
+	if err := checkWindow(x, k, opts); err != nil {
+		return nil, err
+	}
+	return MovApply(x, k, opts, fn), nil
+}
+
+// MovStdDevE is like MovStdDev, but returns an error for empty or NaN only
+// input, a window length smaller than one, or weights not matching the
+// window length.
+func MovStdDevE(x, weights []float64, k int, omitNaNs, trailing, fullWnd bool) ([]float64, error) {
+	if err := checkWindow(x, k, WindowOpts{Weights: weights, OmitNaNs: omitNaNs}); err != nil {
+		return nil, err
+	}
+	return MovStdDev(x, weights, k, omitNaNs, trailing, fullWnd), nil
+}
@@ -0,0 +1,110 @@
+package gostat
+
+import (
+	"github.com/gonum/stat"
+	"math"
+	"testing"
+)
+
+func TestMADE(t *testing.T) {
+	mad, err := MADE([]float64{2., 6., 6., 12., 17., 25., 32.})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if got, want := mad, 8.8956; !floatEquals(got, want) {
+		t.Errorf("Expected MAD=%f, got=%f", want, got)
+	}
+}
+
+func TestMADE_Errors(t *testing.T) {
+	cases := []struct {
+		x   []float64
+		err error
+	}{
+		{nil, ErrEmptyInput},
+		{[]float64{}, ErrEmptyInput},
+		{[]float64{math.NaN(), math.NaN()}, ErrNaNInput},
+	}
+	for _, c := range cases {
+		mad, err := MADE(c.x)
+		if err != c.err {
+			t.Errorf("Expected error=%v, got=%v", c.err, err)
+		}
+		if !math.IsNaN(mad) {
+			t.Errorf("Expected MAD=NaN, got=%f", mad)
+		}
+	}
+}
+
+func TestMedianE(t *testing.T) {
+	if _, err := MedianE(nil); err != ErrEmptyInput {
+		t.Errorf("Expected error=%v, got=%v", ErrEmptyInput, err)
+	}
+	median, err := MedianE([]float64{3., 1., 2.})
+	if err != nil || median != 2. {
+		t.Errorf("Expected median=2, got=%f (%v)", median, err)
+	}
+}
+
+func TestQuantileE(t *testing.T) {
+	if _, err := QuantileE([]float64{1., 2.}, -0.1, QuantileLinear); err != ErrInvalidProbability {
+		t.Errorf("Expected error=%v, got=%v", ErrInvalidProbability, err)
+	}
+	if _, err := QuantileE(nil, 0.5, QuantileLinear); err != ErrEmptyInput {
+		t.Errorf("Expected error=%v, got=%v", ErrEmptyInput, err)
+	}
+}
+
+func TestNormalizeE(t *testing.T) {
+	if _, err := NormalizeE([]float64{1., 2.}, []float64{1.}); err != ErrLengthMismatch {
+		t.Errorf("Expected error=%v, got=%v", ErrLengthMismatch, err)
+	}
+	if _, err := NormalizeE(nil, nil); err != ErrEmptyInput {
+		t.Errorf("Expected error=%v, got=%v", ErrEmptyInput, err)
+	}
+}
+
+func TestVolatilityE(t *testing.T) {
+	if _, err := VolatilityE([]float64{100.}, 252.); err != ErrEmptyInput {
+		t.Errorf("Expected error=%v, got=%v", ErrEmptyInput, err)
+	}
+}
+
+func TestMovApplyE(t *testing.T) {
+	x := []float64{1., 2., 3.}
+	cases := []struct {
+		x    []float64
+		k    int
+		opts WindowOpts
+		err  error
+	}{
+		{x, 0, WindowOpts{}, ErrInvalidWindow},
+		{x, -1, WindowOpts{}, ErrInvalidWindow},
+		{nil, 2, WindowOpts{}, ErrEmptyInput},
+		{[]float64{math.NaN(), math.Inf(1)}, 2, WindowOpts{OmitNaNs: true}, ErrNaNInput},
+		{x, 2, WindowOpts{Weights: []float64{1., 2., 3.}}, ErrLengthMismatch},
+	}
+	for _, c := range cases {
+		if _, err := MovApplyE(c.x, c.k, c.opts, stat.StdDev); err != c.err {
+			t.Errorf("Expected error=%v, got=%v", c.err, err)
+		}
+	}
+
+	m, err := MovApplyE(x, 2, WindowOpts{FullWindow: true}, stat.StdDev)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	compareArrays([]float64{0.7071, 0.7071}, m, t)
+}
+
+func TestRollingWindowE(t *testing.T) {
+	if _, err := RollingWindowE([]float64{1., 2.}, 0, false, false, false); err != ErrInvalidWindow {
+		t.Errorf("Expected error=%v, got=%v", ErrInvalidWindow, err)
+	}
+}
+
+func TestMovStdDevE(t *testing.T) {
+	if _, err := MovStdDevE([]float64{1., 2.}, []float64{1.}, 2, false, false, false); err != ErrLengthMismatch {
+		t.Errorf("Expected error=%v, got=%v", ErrLengthMismatch, err)
+	}
+}
@@ -0,0 +1,92 @@
+package gostat
+
+import (
+	"math"
+)
+
+// EWMCovariance returns the exponentially weighted moving covariance of two
+// series of returns x and y, using the RiskMetrics recursion
+//
+//	cov[t] = lambda*cov[t-1] + (1-lambda)*x[t]*y[t]
+//
+// started from cov[0] = x[0]*y[0]. The returns are assumed to have zero
+// mean, and lambda is the decay factor, 0.94 for daily RiskMetrics data.
+func EWMCovariance(x, y []float64, lambda float64) []float64 {
+	if len(x) != len(y) {
+		panic("gostat: slice length mismatch")
+	}
+	cov := make([]float64, len(x))
+	for i := 0; i < len(x); i++ {
+		if i == 0 {
+			cov[i] = x[i] * y[i]
+			continue
+		}
+		cov[i] = lambda*cov[i-1] + (1-lambda)*x[i]*y[i]
+	}
+	return cov
+}
+
+// EWMCorrelation returns the exponentially weighted moving correlation of
+// two series of returns x and y, the EWMCovariance of x and y divided by the
+// product of their exponentially weighted standard deviations.
+func EWMCorrelation(x, y []float64, lambda float64) []float64 {
+	cov := EWMCovariance(x, y, lambda)
+	varX := EWMCovariance(x, x, lambda)
+	varY := EWMCovariance(y, y, lambda)
+	for i := 0; i < len(cov); i++ {
+		cov[i] /= math.Sqrt(varX[i] * varY[i])
+	}
+	return cov
+}
+
+// EWMA returns the exponentially weighted moving average of x, using the
+// recursion
+//
+//	ewma[t] = alpha*x[t] + (1-alpha)*ewma[t-1]
+//
+// started from ewma[0] = x[0], where alpha in (0, 1] is the smoothing factor.
+// Larger alpha discounts older observations faster.
+func EWMA(x []float64, alpha float64) []float64 {
+	ewma := make([]float64, len(x))
+	for i := 0; i < len(x); i++ {
+		if i == 0 {
+			ewma[i] = x[i]
+			continue
+		}
+		ewma[i] = alpha*x[i] + (1-alpha)*ewma[i-1]
+	}
+	return ewma
+}
+
+// EWMStdDev returns the exponentially weighted moving standard deviation of
+// x around its EWMA with smoothing factor alpha. The variance is updated
+// recursively and is not corrected for bias, so the first value is zero.
+func EWMStdDev(x []float64, alpha float64) []float64 {
+	stdDevs := make([]float64, len(x))
+	var mean, variance float64
+	for i := 0; i < len(x); i++ {
+		if i == 0 {
+			mean = x[i]
+			continue
+		}
+		d := x[i] - mean
+		incr := alpha * d
+		mean += incr
+		variance = (1 - alpha) * (variance + d*incr)
+		stdDevs[i] = math.Sqrt(variance)
+	}
+	return stdDevs
+}
+
+// EWMVolatility calculates historical volatility as annualized exponentially
+// weighted standard deviation of logarithmic returns of prices x, with decay
+// factor lambda as in EWMCovariance. Unlike Volatility, which weights all
+// returns equally, recent returns have a larger influence on the estimate.
+func EWMVolatility(x []float64, lambda, periodicity float64) float64 {
+	rets := LogReturns(x)
+	if len(rets) == 0 {
+		return math.NaN()
+	}
+	variance := EWMCovariance(rets, rets, lambda)
+	return math.Sqrt(variance[len(variance)-1] * periodicity)
+}
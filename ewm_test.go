@@ -0,0 +1,51 @@
+package gostat
+
+import (
+	"math"
+	"testing"
+)
+
+func TestEWMCovariance(t *testing.T) {
+	x := []float64{1., 2., 3.}
+	y := []float64{2., 1., 0.}
+	compareArrays([]float64{2., 2., 1.}, EWMCovariance(x, y, 0.5), t)
+	compareArrays([]float64{1., 2.5, 5.75}, EWMCovariance(x, x, 0.5), t)
+}
+
+func TestEWMCorrelation(t *testing.T) {
+	x := []float64{1., 2., 3.}
+	y := []float64{2., 1., 0.}
+	compareArrays([]float64{1., 0.8, 0.3730}, EWMCorrelation(x, y, 0.5), t)
+}
+
+func TestEWMCovariance_LengthMismatch(t *testing.T) {
+	defer func() {
+		if r := recover(); r == nil {
+			t.Errorf("Expected panic on slice length mismatch")
+		}
+	}()
+	EWMCovariance([]float64{1., 2.}, []float64{1.}, 0.94)
+}
+
+func TestEWMA(t *testing.T) {
+	x := []float64{1., 2., 3.}
+	compareArrays([]float64{1., 1.5, 2.25}, EWMA(x, 0.5), t)
+}
+
+func TestEWMStdDev(t *testing.T) {
+	x := []float64{1., 2., 3.}
+	compareArrays([]float64{0., 0.5, 0.8292}, EWMStdDev(x, 0.5), t)
+}
+
+func TestEWMVolatility(t *testing.T) {
+	prices := []float64{100., 101., 100., 102.}
+	if got, want := EWMVolatility(prices, 0.94, 252.), 0.1714; !floatEquals(got, want) {
+		t.Errorf("Expected volatility=%f, got=%f", want, got)
+	}
+}
+
+func TestEWMVolatility_SinglePrice(t *testing.T) {
+	if got := EWMVolatility([]float64{100.}, 0.94, 252.); !math.IsNaN(got) {
+		t.Errorf("Expected volatility=NaN, got=%f", got)
+	}
+}
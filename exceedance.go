@@ -0,0 +1,38 @@
+package gostat
+
+// Exceedance is an episode of consecutive values of a series above a
+// threshold, returned by Exceedances.
+type Exceedance struct {
+	Run
+	// Max is the largest value of the episode.
+	Max float64
+	// Area is the sum of the amounts by which the values of the episode
+	// exceed the threshold.
+	Area float64
+}
+
+// Exceedances returns the episodes where the values of x exceed threshold,
+// such as drawdowns beyond a risk limit or spells of extreme volatility,
+// summarizing each run of consecutive values above threshold by its largest
+// value and the total excess over threshold. NaN values end an episode. See
+// Flatlines for runs of repeated values.
+func Exceedances(x []float64, threshold float64) []Exceedance {
+	var episodes []Exceedance
+	var cur *Exceedance
+	for i := 0; i < len(x); i++ {
+		if !(x[i] > threshold) {
+			cur = nil
+			continue
+		}
+		if cur == nil {
+			episodes = append(episodes, Exceedance{Run: Run{i, i}, Max: x[i]})
+			cur = &episodes[len(episodes)-1]
+		}
+		cur.End = i + 1
+		if x[i] > cur.Max {
+			cur.Max = x[i]
+		}
+		cur.Area += x[i] - threshold
+	}
+	return episodes
+}
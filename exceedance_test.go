@@ -0,0 +1,30 @@
+package gostat
+
+import (
+	"math"
+	"testing"
+)
+
+func TestExceedances(t *testing.T) {
+	x := []float64{1., 3., 4., 2., 0., 5., math.NaN(), 6., 2.5}
+	got := Exceedances(x, 2)
+	want := []Exceedance{
+		{Run{1, 3}, 4., 3.},
+		{Run{5, 6}, 5., 3.},
+		{Run{7, 9}, 6., 4.5},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("Expected exceedances=%v, got=%v", want, got)
+	}
+	for i := 0; i < len(want); i++ {
+		if got[i].Run != want[i].Run || got[i].Len() != want[i].Len() {
+			t.Errorf("Expected run=%v, got=%v", want[i].Run, got[i].Run)
+		}
+		if !floatEquals(got[i].Max, want[i].Max) || !floatEquals(got[i].Area, want[i].Area) {
+			t.Errorf("Expected max=%f area=%f, got=%f, %f", want[i].Max, want[i].Area, got[i].Max, got[i].Area)
+		}
+	}
+	if got := Exceedances(x, 10); len(got) != 0 {
+		t.Errorf("Expected no exceedances, got=%v", got)
+	}
+}
@@ -0,0 +1,120 @@
+//go:build go1.18
+// +build go1.18
+
+package gostat
+
+import (
+	"math"
+	"sort"
+)
+
+// Number is a constraint permitting any integer or floating point type.
+type Number interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 |
+		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr |
+		~float32 | ~float64
+}
+
+// MeanOf returns the arithmetic mean of x, NaN for an empty slice.
+func MeanOf[T Number](x []T) float64 {
+	return MeanFunc(x, toFloat[T])
+}
+
+// MeanFunc returns the arithmetic mean of the values extracted by f from the
+// elements of x, NaN for an empty slice.
+func MeanFunc[T any](x []T, f func(T) float64) float64 {
+	if len(x) == 0 {
+		return math.NaN()
+	}
+	var sum neumaierSum
+	for i := 0; i < len(x); i++ {
+		sum.add(f(x[i]))
+	}
+	return sum.total() / float64(len(x))
+}
+
+// StdDevOf returns the unbiased sample standard deviation of x, NaN for
+// fewer than two values.
+func StdDevOf[T Number](x []T) float64 {
+	return StdDevFunc(x, toFloat[T])
+}
+
+// StdDevFunc returns the unbiased sample standard deviation of the values
+// extracted by f from the elements of x, NaN for fewer than two values. It
+// uses the same corrected two-pass algorithm as stat.StdDev.
+func StdDevFunc[T any](x []T, f func(T) float64) float64 {
+	if len(x) < 2 {
+		return math.NaN()
+	}
+	mean := MeanFunc(x, f)
+	var ss, comp float64
+	for i := 0; i < len(x); i++ {
+		d := f(x[i]) - mean
+		ss += d * d
+		comp += d
+	}
+	n := float64(len(x))
+	return math.Sqrt((ss - comp*comp/n) / (n - 1))
+}
+
+// MedianOf returns the median of x, NaN for an empty slice, see Median.
+func MedianOf[T Number](x []T) float64 {
+	return MedianFunc(x, toFloat[T])
+}
+
+// MedianFunc returns the median of the values extracted by f from the
+// elements of x, NaN for an empty slice, see Median.
+func MedianFunc[T any](x []T, f func(T) float64) float64 {
+	if len(x) == 0 {
+		return math.NaN()
+	}
+	return medianSorted(sortedValues(x, f))
+}
+
+// MADOf returns the median absolute deviation of x, NaN for an empty slice,
+// see MAD.
+func MADOf[T Number](x []T) float64 {
+	return MADFunc(x, toFloat[T])
+}
+
+// MADFunc returns the median absolute deviation of the values extracted by f
+// from the elements of x, NaN for an empty slice, see MAD.
+func MADFunc[T any](x []T, f func(T) float64) float64 {
+	if len(x) == 0 {
+		return math.NaN()
+	}
+	series := sortedValues(x, f)
+	median := medianSorted(series)
+	for i := 0; i < len(series); i++ {
+		series[i] = math.Abs(median - series[i])
+	}
+	sort.Float64s(series)
+	return 1.4826 * medianSorted(series)
+}
+
+// QuantileOf returns the p-quantile of x using the given method, see
+// Quantile.
+func QuantileOf[T Number](x []T, p float64, method QuantileMethod) float64 {
+	return QuantileFunc(x, toFloat[T], p, method)
+}
+
+// QuantileFunc returns the p-quantile of the values extracted by f from the
+// elements of x using the given method, see Quantile.
+func QuantileFunc[T any](x []T, f func(T) float64, p float64, method QuantileMethod) float64 {
+	return quantileSorted(sortedValues(x, f), p, method)
+}
+
+func toFloat[T Number](v T) float64 {
+	return float64(v)
+}
+
+// sortedValues returns the values extracted by f from x sorted from lowest
+// to highest in a single newly allocated slice.
+func sortedValues[T any](x []T, f func(T) float64) []float64 {
+	series := make([]float64, len(x))
+	for i := 0; i < len(x); i++ {
+		series[i] = f(x[i])
+	}
+	sort.Float64s(series)
+	return series
+}
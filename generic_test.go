@@ -0,0 +1,86 @@
+//go:build go1.18
+// +build go1.18
+
+package gostat
+
+import (
+	"github.com/gonum/stat"
+	"math"
+	"testing"
+)
+
+type candle struct {
+	Open, Close float64
+	Volume      int64
+}
+
+var testCandles = []candle{
+	{Open: 10., Close: 11., Volume: 100},
+	{Open: 11., Close: 10.5, Volume: 250},
+	{Open: 10.5, Close: 12., Volume: 80},
+	{Open: 12., Close: 13.5, Volume: 300},
+}
+
+func closePrice(c candle) float64 { return c.Close }
+
+func TestMedianOf(t *testing.T) {
+	x := []int64{7, 1, 4, 2}
+	if got, want := MedianOf(x), 3.; !floatEquals(got, want) {
+		t.Errorf("Expected median=%f, got=%f", want, got)
+	}
+	if got, want := MedianOf([]float32{3, 1, 2}), 2.; !floatEquals(got, want) {
+		t.Errorf("Expected median=%f, got=%f", want, got)
+	}
+	if got := MedianOf([]int{}); !math.IsNaN(got) {
+		t.Errorf("Expected median=NaN, got=%f", got)
+	}
+}
+
+func TestMedianFunc(t *testing.T) {
+	if got, want := MedianFunc(testCandles, closePrice), Median([]float64{11., 10.5, 12., 13.5}); !floatEquals(got, want) {
+		t.Errorf("Expected median=%f, got=%f", want, got)
+	}
+}
+
+func TestMADOf(t *testing.T) {
+	x := []int{2, 6, 6, 12, 17, 25, 32}
+	if got, want := MADOf(x), MAD([]float64{2., 6., 6., 12., 17., 25., 32.}); !floatEquals(got, want) {
+		t.Errorf("Expected MAD=%f, got=%f", want, got)
+	}
+	if got := MADFunc([]candle{}, closePrice); !math.IsNaN(got) {
+		t.Errorf("Expected MAD=NaN, got=%f", got)
+	}
+}
+
+func TestMeanOf(t *testing.T) {
+	if got, want := MeanOf([]uint8{1, 2, 3, 6}), 3.; !floatEquals(got, want) {
+		t.Errorf("Expected mean=%f, got=%f", want, got)
+	}
+	volume := func(c candle) float64 { return float64(c.Volume) }
+	if got, want := MeanFunc(testCandles, volume), 182.5; !floatEquals(got, want) {
+		t.Errorf("Expected mean=%f, got=%f", want, got)
+	}
+}
+
+func TestStdDevOf(t *testing.T) {
+	x := []float64{11., 10.5, 12., 13.5}
+	if got, want := StdDevFunc(testCandles, closePrice), stat.StdDev(x, nil); !floatEquals(got, want) {
+		t.Errorf("Expected std dev=%f, got=%f", want, got)
+	}
+	if got, want := StdDevOf([]int{2, 4, 4, 4, 5, 5, 7, 9}), stat.StdDev([]float64{2., 4., 4., 4., 5., 5., 7., 9.}, nil); !floatEquals(got, want) {
+		t.Errorf("Expected std dev=%f, got=%f", want, got)
+	}
+	if got := StdDevOf([]int{1}); !math.IsNaN(got) {
+		t.Errorf("Expected std dev=NaN, got=%f", got)
+	}
+}
+
+func TestQuantileOf(t *testing.T) {
+	x := []int32{1, 2, 3, 4, 5}
+	if got, want := QuantileOf(x, 0.25, QuantileLinear), 2.; !floatEquals(got, want) {
+		t.Errorf("Expected quantile=%f, got=%f", want, got)
+	}
+	if got, want := QuantileFunc(testCandles, closePrice, 0.5, QuantileLower), 11.; !floatEquals(got, want) {
+		t.Errorf("Expected quantile=%f, got=%f", want, got)
+	}
+}
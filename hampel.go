@@ -0,0 +1,70 @@
+package gostat
+
+import (
+	"math"
+)
+
+// Hampel returns the indices of the elements of x flagged by the Hampel
+// identifier, which compares each element with the median of the centered
+// window of length k around it, and flags it when it deviates from the
+// median by more than threshold times the MAD of the window. A commonly
+// used threshold is 3.
+func Hampel(x []float64, k int, threshold float64) []int {
+	scores := hampelScores(x, k)
+	return outliers(scores, func(z float64) bool {
+		return math.Abs(z) > threshold
+	})
+}
+
+// HampelMultivariate extends the Hampel identifier to aligned series, where
+// series[j][i] is the i-th observation of the j-th series. Each observation
+// is scored in every series by its deviation from the rolling median in
+// units of the rolling MAD, as calculated by Hampel over centered windows
+// of length k, and the scores are combined into the robust distance
+// sqrt(z1^2 + ... + zp^2). It returns the distances and the indices of the
+// observations whose distance exceeds threshold, so that joint outliers
+// that are only moderately unusual in each series are flagged too. Under
+// normality the squared distance follows the chi-square distribution with p
+// degrees of freedom, so the square root of its 0.99 quantile is a natural
+// threshold.
+//
+// A window with zero MAD scores an observation equal to its median as zero
+// and any other observation as infinitely far.
+func HampelMultivariate(series [][]float64, k int, threshold float64) ([]float64, []int, error) {
+	if err := checkPanel(series, 1); err != nil {
+		return nil, nil, err
+	}
+	if k < 1 {
+		return nil, nil, ErrInvalidWindow
+	}
+	dist := make([]float64, len(series[0]))
+	for j := 0; j < len(series); j++ {
+		for i, z := range hampelScores(series[j], k) {
+			dist[i] += z * z
+		}
+	}
+	for i := 0; i < len(dist); i++ {
+		dist[i] = math.Sqrt(dist[i])
+	}
+	return dist, outliers(dist, func(d float64) bool {
+		return d > threshold
+	}), nil
+}
+
+// hampelScores returns the deviations of x from its centered moving median
+// in units of its centered moving MAD.
+func hampelScores(x []float64, k int) []float64 {
+	medians := MovMedian(x, k, WindowOpts{})
+	mads := MovMAD(x, k, WindowOpts{})
+	scores := make([]float64, len(x))
+	for i := 0; i < len(x); i++ {
+		d := x[i] - medians[i]
+		switch {
+		case mads[i] != 0:
+			scores[i] = d / mads[i]
+		case d != 0:
+			scores[i] = math.Inf(1)
+		}
+	}
+	return scores
+}
@@ -0,0 +1,37 @@
+package gostat
+
+import (
+	"testing"
+)
+
+func TestHampel(t *testing.T) {
+	x := []float64{1., 2., 3., 20., 5., 6., 7.}
+	compareIndices([]int{3}, Hampel(x, 5, 3.), t)
+}
+
+func TestHampel_Flat(t *testing.T) {
+	x := []float64{1., 1., 1., 2., 1., 1., 1.}
+	compareIndices([]int{3}, Hampel(x, 5, 3.), t)
+}
+
+func TestHampelMultivariate(t *testing.T) {
+	x := []float64{1., 1.1, 0.9, 1., 5., 1.05, 0.95, 1.}
+	y := []float64{2., 2.1, 1.9, 2., 6., 2.05, 1.95, 2.}
+	dist, idx, err := HampelMultivariate([][]float64{x, y}, 5, 5.)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	compareIndices([]int{4}, idx, t)
+	if got, want := dist[4], 76.3099; !floatEquals(got, want) {
+		t.Errorf("Expected distance=%f, got=%f", want, got)
+	}
+}
+
+func TestHampelMultivariate_Errors(t *testing.T) {
+	if _, _, err := HampelMultivariate([][]float64{{1., 2.}, {1.}}, 3, 3.); err != ErrLengthMismatch {
+		t.Errorf("Expected error=%v, got=%v", ErrLengthMismatch, err)
+	}
+	if _, _, err := HampelMultivariate([][]float64{{1., 2.}}, 0, 3.); err != ErrInvalidWindow {
+		t.Errorf("Expected error=%v, got=%v", ErrInvalidWindow, err)
+	}
+}
@@ -0,0 +1,440 @@
+package gostat
+
+import (
+	"math"
+)
+
+// accumulator maintains a statistic over a sliding window, which is updated
+// incrementally as elements enter and leave the window. Elements always
+// leave the window in the same order they entered it.
+type accumulator interface {
+	push(x float64)
+	pop(x float64)
+	value() float64
+}
+
+// movAccumulate returns the values of acc over the sliding windows of
+// length k across x, updating it incrementally instead of recomputing the
+// statistic for every window. Weights in opts are ignored.
+func movAccumulate(x []float64, k int, opts WindowOpts, acc accumulator) []float64 {
+	v := windowSeries(x, opts)
+	skip := opts.NaNPolicy == NaNSkip
+	it := windowIterFor(x, v, k, opts)
+	rets := make([]float64, 0, it.len())
+	lo, hi := 0, 0
+	for it.next() {
+		if it.pad {
+			rets = append(rets, math.NaN())
+			continue
+		}
+		for ; hi < it.end; hi++ {
+			if !skip || !math.IsNaN(v[hi]) {
+				acc.push(v[hi])
+			}
+		}
+		for ; lo < it.start; lo++ {
+			if !skip || !math.IsNaN(v[lo]) {
+				acc.pop(v[lo])
+			}
+		}
+		rets = append(rets, acc.value())
+	}
+	return rets
+}
+
+// nonFinite counts NaN and infinite values in a window, which are kept out
+// of the running sums so that they do not corrupt them once removed.
+type nonFinite struct {
+	nan, posInf, negInf int
+}
+
+func (nf *nonFinite) update(x float64, delta int) bool {
+	switch {
+	case math.IsNaN(x):
+		nf.nan += delta
+	case math.IsInf(x, 1):
+		nf.posInf += delta
+	case math.IsInf(x, -1):
+		nf.negInf += delta
+	default:
+		return false
+	}
+	return true
+}
+
+// sum returns the sum the non-finite values would contribute to a window,
+// or zero if the window has none.
+func (nf *nonFinite) sum() float64 {
+	switch {
+	case nf.nan > 0 || (nf.posInf > 0 && nf.negInf > 0):
+		return math.NaN()
+	case nf.posInf > 0:
+		return math.Inf(1)
+	case nf.negInf > 0:
+		return math.Inf(-1)
+	}
+	return 0
+}
+
+func (nf *nonFinite) any() bool {
+	return nf.nan+nf.posInf+nf.negInf > 0
+}
+
+// sumAcc is a running compensated sum, or mean if mean is set.
+type sumAcc struct {
+	nonFinite
+	neumaierSum
+	mean bool
+	n    int
+}
+
+func (a *sumAcc) push(x float64) {
+	a.n++
+	if !a.update(x, 1) {
+		a.add(x)
+	}
+}
+
+func (a *sumAcc) pop(x float64) {
+	a.n--
+	if !a.update(x, -1) {
+		a.add(-x)
+	}
+}
+
+func (a *sumAcc) value() float64 {
+	if a.any() {
+		return a.nonFinite.sum()
+	}
+	if a.mean {
+		return a.total() / float64(a.n)
+	}
+	return a.total()
+}
+
+// varAcc is a running sample variance, or standard deviation if std is set,
+// using Welford's updates.
+type varAcc struct {
+	nonFinite
+	std      bool
+	n        int
+	mean, m2 float64
+}
+
+func (a *varAcc) push(x float64) {
+	if a.update(x, 1) {
+		return
+	}
+	a.n++
+	d := x - a.mean
+	a.mean += d / float64(a.n)
+	a.m2 += d * (x - a.mean)
+}
+
+func (a *varAcc) pop(x float64) {
+	if a.update(x, -1) {
+		return
+	}
+	a.n--
+	if a.n == 0 {
+		a.mean, a.m2 = 0, 0
+		return
+	}
+	d := x - a.mean
+	a.mean -= d / float64(a.n)
+	a.m2 -= d * (x - a.mean)
+}
+
+func (a *varAcc) value() float64 {
+	if a.any() || a.n < 2 {
+		return math.NaN()
+	}
+	v := math.Max(a.m2, 0) / float64(a.n-1)
+	if a.std {
+		return math.Sqrt(v)
+	}
+	return v
+}
+
+// extremumAcc is a running maximum for positive sign or minimum for negative
+// sign, kept in a monotonic deque of the candidate elements.
+type extremumAcc struct {
+	sign           float64
+	nan            int
+	pushed, popped int
+	head           int
+	seq            []int
+	vals           []float64
+}
+
+func (a *extremumAcc) push(x float64) {
+	seq := a.pushed
+	a.pushed++
+	if math.IsNaN(x) {
+		a.nan++
+		return
+	}
+	for len(a.vals) > a.head && a.sign*a.vals[len(a.vals)-1] <= a.sign*x {
+		a.vals = a.vals[:len(a.vals)-1]
+		a.seq = a.seq[:len(a.seq)-1]
+	}
+	if a.head > 0 && 2*a.head >= len(a.vals) {
+		// compact the deque so it never grows beyond the window length
+		n := copy(a.vals, a.vals[a.head:])
+		copy(a.seq, a.seq[a.head:])
+		a.vals, a.seq, a.head = a.vals[:n], a.seq[:n], 0
+	}
+	a.vals = append(a.vals, x)
+	a.seq = append(a.seq, seq)
+}
+
+func (a *extremumAcc) pop(x float64) {
+	if math.IsNaN(x) {
+		a.nan--
+	} else if a.head < len(a.seq) && a.seq[a.head] == a.popped {
+		a.head++
+	}
+	a.popped++
+}
+
+func (a *extremumAcc) value() float64 {
+	if a.nan > 0 || a.head == len(a.vals) {
+		return math.NaN()
+	}
+	return a.vals[a.head]
+}
+
+// medianAcc is a running median, kept in an orderTree of the window
+// elements.
+type medianAcc struct {
+	nan  int
+	tree *orderTree
+}
+
+func newMedianAcc(k int) *medianAcc {
+	return &medianAcc{tree: newOrderTree(k)}
+}
+
+func (a *medianAcc) push(x float64) {
+	if math.IsNaN(x) {
+		a.nan++
+		return
+	}
+	a.tree.insert(x)
+}
+
+func (a *medianAcc) pop(x float64) {
+	if math.IsNaN(x) {
+		a.nan--
+		return
+	}
+	a.tree.remove(x)
+}
+
+func (a *medianAcc) value() float64 {
+	n := a.tree.len()
+	if a.nan > 0 || n == 0 {
+		return math.NaN()
+	}
+	k := n / 2
+	if n%2 == 1 {
+		return a.tree.at(k)
+	}
+	return 0.5 * (a.tree.at(k-1) + a.tree.at(k))
+}
+
+// madAcc is a running median absolute deviation. The absolute deviations
+// below and above the median are two sorted sequences read from the
+// orderTree of the window elements, and their median is selected by binary
+// search over both, in O(log^2 k) time.
+type madAcc struct {
+	medianAcc
+}
+
+func newMADAcc(k int) *madAcc {
+	return &madAcc{medianAcc{tree: newOrderTree(k)}}
+}
+
+func (a *madAcc) value() float64 {
+	m := a.medianAcc.value()
+	if math.IsNaN(m) {
+		return m
+	}
+	t := a.tree
+	n := t.len()
+	c := t.rank(m)
+	below := func(i int) float64 { return m - t.at(c-1-i) }
+	above := func(i int) float64 { return t.at(c+i) - m }
+	// j-th lowest deviation, taking i from below and j+1-i from above
+	dev := func(j int) float64 {
+		lo, hi := maxInt(0, j+1-(n-c)), minInt(c, j+1)
+		for lo < hi {
+			i := (lo + hi) / 2
+			if below(i) < above(j-i) {
+				lo = i + 1
+			} else {
+				hi = i
+			}
+		}
+		d := math.Inf(-1)
+		if lo > 0 {
+			d = below(lo - 1)
+		}
+		if lo <= j {
+			d = math.Max(d, above(j-lo))
+		}
+		return d
+	}
+	k := n / 2
+	mad := dev(k)
+	if n%2 == 0 {
+		mad = 0.5 * (dev(k-1) + mad)
+	}
+	return 1.4826 * mad
+}
+
+// orderTree is a treap of the values in a window, a binary search tree
+// balanced by random priorities and augmented with the size of each
+// subtree, so that values are inserted, removed and selected by rank in
+// O(log k) expected time.
+type orderTree struct {
+	// nodes[0] is the empty tree, and the nodes of removed values are
+	// reused
+	nodes []treapNode
+	free  []int
+	root  int
+	seed  uint32
+}
+
+type treapNode struct {
+	val         float64
+	pri         uint32
+	left, right int
+	size        int
+}
+
+func newOrderTree(k int) *orderTree {
+	return &orderTree{nodes: make([]treapNode, 1, maxInt(k, 0)+1), seed: 2463534242}
+}
+
+// len returns the number of values in the tree.
+func (t *orderTree) len() int {
+	return t.nodes[t.root].size
+}
+
+func (t *orderTree) insert(x float64) {
+	l, r := t.split(t.root, x)
+	t.root = t.merge(t.merge(l, t.alloc(x)), r)
+}
+
+// remove removes one occurrence of x, which must be in the tree.
+func (t *orderTree) remove(x float64) {
+	t.root = t.removeFrom(t.root, x)
+}
+
+// at returns the value of rank r, 0 for the lowest value.
+func (t *orderTree) at(r int) float64 {
+	i := t.root
+	for {
+		l := t.nodes[i].left
+		switch s := t.nodes[l].size; {
+		case r < s:
+			i = l
+		case r == s:
+			return t.nodes[i].val
+		default:
+			r -= s + 1
+			i = t.nodes[i].right
+		}
+	}
+}
+
+// rank returns the number of values lower than x.
+func (t *orderTree) rank(x float64) int {
+	var r int
+	for i := t.root; i != 0; {
+		if t.nodes[i].val < x {
+			r += t.nodes[t.nodes[i].left].size + 1
+			i = t.nodes[i].right
+		} else {
+			i = t.nodes[i].left
+		}
+	}
+	return r
+}
+
+func (t *orderTree) removeFrom(i int, x float64) int {
+	if i == 0 {
+		return 0
+	}
+	switch v := t.nodes[i].val; {
+	case x < v:
+		t.nodes[i].left = t.removeFrom(t.nodes[i].left, x)
+	case x > v:
+		t.nodes[i].right = t.removeFrom(t.nodes[i].right, x)
+	default:
+		t.free = append(t.free, i)
+		return t.merge(t.nodes[i].left, t.nodes[i].right)
+	}
+	t.resize(i)
+	return i
+}
+
+// split splits tree i into the trees of the values lower than x and of the
+// other values.
+func (t *orderTree) split(i int, x float64) (int, int) {
+	if i == 0 {
+		return 0, 0
+	}
+	if t.nodes[i].val < x {
+		l, r := t.split(t.nodes[i].right, x)
+		t.nodes[i].right = l
+		t.resize(i)
+		return i, r
+	}
+	l, r := t.split(t.nodes[i].left, x)
+	t.nodes[i].left = r
+	t.resize(i)
+	return l, i
+}
+
+// merge joins trees a and b, where the values of a are not greater than
+// those of b.
+func (t *orderTree) merge(a, b int) int {
+	if a == 0 {
+		return b
+	}
+	if b == 0 {
+		return a
+	}
+	if t.nodes[a].pri > t.nodes[b].pri {
+		t.nodes[a].right = t.merge(t.nodes[a].right, b)
+		t.resize(a)
+		return a
+	}
+	t.nodes[b].left = t.merge(a, t.nodes[b].left)
+	t.resize(b)
+	return b
+}
+
+func (t *orderTree) resize(i int) {
+	t.nodes[i].size = 1 + t.nodes[t.nodes[i].left].size + t.nodes[t.nodes[i].right].size
+}
+
+// alloc returns a new node of x with a pseudo-random priority drawn by
+// xorshift, so that the shape of the tree does not depend on the order of
+// the values.
+func (t *orderTree) alloc(x float64) int {
+	t.seed ^= t.seed << 13
+	t.seed ^= t.seed >> 17
+	t.seed ^= t.seed << 5
+	node := treapNode{val: x, pri: t.seed, size: 1}
+	if n := len(t.free); n > 0 {
+		i := t.free[n-1]
+		t.free = t.free[:n-1]
+		t.nodes[i] = node
+		return i
+	}
+	t.nodes = append(t.nodes, node)
+	return len(t.nodes) - 1
+}
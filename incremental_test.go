@@ -0,0 +1,97 @@
+package gostat
+
+import (
+	"github.com/gonum/floats"
+	"github.com/gonum/stat"
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func TestMovAccumulate_MatchesMovApply(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	x := make([]float64, 200)
+	for i := 0; i < len(x); i++ {
+		x[i] = 100. + rnd.NormFloat64()
+	}
+	x[50], x[120] = math.NaN(), math.Inf(1)
+
+	withNaN := func(fn func([]float64) float64) func(window, _ []float64) float64 {
+		return func(window, _ []float64) float64 {
+			if floats.HasNaN(window) {
+				return math.NaN()
+			}
+			return fn(window)
+		}
+	}
+	cases := []struct {
+		name string
+		acc  func(k int) accumulator
+		fn   func(window, weights []float64) float64
+	}{
+		{"sum", func(int) accumulator { return &sumAcc{} }, func(w, _ []float64) float64 { return floats.Sum(w) }},
+		{"mean", func(int) accumulator { return &sumAcc{mean: true} }, stat.Mean},
+		{"variance", func(int) accumulator { return &varAcc{} }, stat.Variance},
+		{"stddev", func(int) accumulator { return &varAcc{std: true} }, stat.StdDev},
+		{"min", func(int) accumulator { return &extremumAcc{sign: -1} }, withNaN(floats.Min)},
+		{"max", func(int) accumulator { return &extremumAcc{sign: 1} }, withNaN(floats.Max)},
+		{"median", func(k int) accumulator { return newMedianAcc(k) }, withNaN(Median)},
+		{"mad", func(k int) accumulator { return newMADAcc(k) }, withNaN(MAD)},
+	}
+	opts := []WindowOpts{{}, {Trailing: true}, {FullWindow: true}, {OmitNaNs: true}}
+	for _, c := range cases {
+		for _, k := range []int{1, 2, 7, 30} {
+			for _, o := range opts {
+				want := MovApply(x, k, o, c.fn)
+				got := movAccumulate(x, k, o, c.acc(k))
+				if len(got) != len(want) {
+					t.Fatalf("%s k=%d: Expected number of elements=%d, got=%d", c.name, k, len(want), len(got))
+				}
+				for i := 0; i < len(want); i++ {
+					if !floatEquals(got[i], want[i]) && got[i] != want[i] {
+						t.Errorf("%s k=%d %+v: Expected value at index %d=%f, got=%f", c.name, k, o, i, want[i], got[i])
+					}
+				}
+			}
+		}
+	}
+}
+
+func TestMovMin_Decreasing(t *testing.T) {
+	x := []float64{9., 8., 7., 6., 5., 4., 3., 2., 1.}
+	m := MovMin(x, 3, WindowOpts{Trailing: true})
+	compareArrays([]float64{9., 8., 7., 6., 5., 4., 3., 2., 1.}, m, t)
+	m = MovMax(x, 3, WindowOpts{Trailing: true})
+	compareArrays([]float64{9., 9., 9., 8., 7., 6., 5., 4., 3.}, m, t)
+}
+
+func TestMovMedian_WithNaNs(t *testing.T) {
+	x := []float64{1., 5., math.NaN(), 2., 4., 3.}
+	m := MovMedian(x, 2, WindowOpts{Trailing: true})
+	compareArrays([]float64{1., 3., math.NaN(), math.NaN(), 3., 3.5}, m, t)
+}
+
+func TestMovMin_EmptyWindows(t *testing.T) {
+	x := []float64{math.NaN(), math.NaN(), math.NaN()}
+	opts := WindowOpts{Trailing: true, OmitNaNs: true}
+	for _, m := range [][]float64{MovMin(x, 2, opts), MovMax(x, 2, opts), MovMedian(x, 2, opts)} {
+		for i := 0; i < len(m); i++ {
+			if !math.IsNaN(m[i]) {
+				t.Errorf("Expected value at index %d=NaN, got=%f", i, m[i])
+			}
+		}
+	}
+}
+
+func TestMovMedian_Ties(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	x := make([]float64, 500)
+	for i := 0; i < len(x); i++ {
+		x[i] = float64(rnd.Intn(5))
+	}
+	for _, k := range []int{2, 9, 64} {
+		opts := WindowOpts{Trailing: true}
+		compareArrays(MovApply(x, k, opts, func(w, _ []float64) float64 { return Median(w) }), MovMedian(x, k, opts), t)
+		compareArrays(MovApply(x, k, opts, func(w, _ []float64) float64 { return MAD(w) }), MovMAD(x, k, opts), t)
+	}
+}
@@ -0,0 +1,102 @@
+package gostat
+
+import (
+	"math"
+)
+
+// RollSpread returns the Roll estimator of the effective bid-ask spread
+// from trade or close prices, twice the square root of the negative first
+// order autocovariance of the price changes. The spread is in the units of
+// prices, so log prices give a relative spread. NaN is returned when the
+// autocovariance is not negative, where the estimator is undefined, or for
+// fewer than three prices.
+func RollSpread(prices []float64) float64 {
+	if len(prices) < 3 {
+		return math.NaN()
+	}
+	d := make([]float64, len(prices)-1)
+	for i := 1; i < len(prices); i++ {
+		d[i-1] = prices[i] - prices[i-1]
+	}
+	cov := covariance(d[1:], d[:len(d)-1], nil)
+	if !(cov < 0) {
+		return math.NaN()
+	}
+	return 2 * math.Sqrt(-cov)
+}
+
+// CorwinSchultzSpread returns the Corwin-Schultz estimates of the relative
+// bid-ask spread from the high and low prices of each pair of consecutive
+// days, a slice of len(high)-1 values. The estimator separates the spread
+// from the volatility by comparing the high-low range over one and two days.
+// Negative estimates are set to 0, and the spread over a longer period is
+// usually the mean of the daily estimates.
+func CorwinSchultzSpread(high, low []float64) []float64 {
+	n := checkSeries([][]float64{high, low})
+	if n < 2 {
+		return []float64{}
+	}
+	k := 3 - 2*math.Sqrt2
+	spreads := make([]float64, n-1)
+	for i := 1; i < n; i++ {
+		hl0 := math.Log(high[i-1] / low[i-1])
+		hl1 := math.Log(high[i] / low[i])
+		beta := hl0*hl0 + hl1*hl1
+		hl2 := math.Log(math.Max(high[i-1], high[i]) / math.Min(low[i-1], low[i]))
+		gamma := hl2 * hl2
+		alpha := (math.Sqrt(2*beta)-math.Sqrt(beta))/k - math.Sqrt(gamma/k)
+		s := 2 * (math.Exp(alpha) - 1) / (1 + math.Exp(alpha))
+		spreads[i-1] = math.Max(s, 0)
+	}
+	return spreads
+}
+
+// Amihud returns the Amihud illiquidity measure, the mean ratio of the
+// absolute return to the dollar volume of each period, a proxy for the price
+// impact of trading. Periods without volume are ignored. The ratio is not
+// scaled, so it is often multiplied by 1e6 for readability. NaN is returned
+// when no period has volume.
+func Amihud(returns, dollarVolume []float64) float64 {
+	return meanFinite(amihudRatios(returns, dollarVolume))
+}
+
+// MovAmihud returns moving Amihud illiquidity, a slice of the local k-point
+// Amihud measures over windows selected the same way as by MovApply.
+// Weights are ignored.
+func MovAmihud(returns, dollarVolume []float64, k int, opts WindowOpts) []float64 {
+	return MovApply(amihudRatios(returns, dollarVolume), k, opts, func(window, _ []float64) float64 {
+		return meanFinite(window)
+	})
+}
+
+// amihudRatios returns the ratios of absolute returns to dollar volumes,
+// NaN for periods without volume.
+func amihudRatios(returns, dollarVolume []float64) []float64 {
+	n := checkSeries([][]float64{returns, dollarVolume})
+	ratios := make([]float64, n)
+	for i := 0; i < n; i++ {
+		if dollarVolume[i] > 0 {
+			ratios[i] = math.Abs(returns[i]) / dollarVolume[i]
+		} else {
+			ratios[i] = math.NaN()
+		}
+	}
+	return ratios
+}
+
+// meanFinite returns the mean of the values of x other than NaN, or NaN if
+// there are none.
+func meanFinite(x []float64) float64 {
+	var sum neumaierSum
+	var n int
+	for i := 0; i < len(x); i++ {
+		if !math.IsNaN(x[i]) {
+			sum.add(x[i])
+			n++
+		}
+	}
+	if n == 0 {
+		return math.NaN()
+	}
+	return sum.total() / float64(n)
+}
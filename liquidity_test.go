@@ -0,0 +1,46 @@
+package gostat
+
+import (
+	"math"
+	"testing"
+)
+
+func TestRollSpread(t *testing.T) {
+	prices := []float64{10., 10.1, 10., 10.1, 10.05, 10.15, 10.05, 10.1}
+	if got, want := RollSpread(prices), 0.1897; !floatEquals(got, want) {
+		t.Errorf("Expected spread=%f, got=%f", want, got)
+	}
+	if got := RollSpread([]float64{1., 2., 3., 4.}); !math.IsNaN(got) {
+		t.Errorf("Expected spread=NaN, got=%f", got)
+	}
+}
+
+func TestCorwinSchultzSpread(t *testing.T) {
+	high := []float64{10.2, 10.3, 10.25}
+	low := []float64{9.9, 10., 10.05}
+	compareArrays([]float64{0.0058, 0.0144}, CorwinSchultzSpread(high, low), t)
+	if got := CorwinSchultzSpread(high[:1], low[:1]); len(got) != 0 {
+		t.Errorf("Expected no estimates, got=%v", got)
+	}
+}
+
+func TestAmihud(t *testing.T) {
+	returns := []float64{0.01, -0.02, 0.005, 0.03}
+	volume := []float64{1e6, 2e6, 0., 3e6}
+	if got, want := Amihud(returns, volume)*1e6, 0.01; !floatEquals(got, want) {
+		t.Errorf("Expected illiquidity=%f, got=%f", want, got)
+	}
+	if got := Amihud(returns[2:3], volume[2:3]); !math.IsNaN(got) {
+		t.Errorf("Expected illiquidity=NaN, got=%f", got)
+	}
+}
+
+func TestMovAmihud(t *testing.T) {
+	returns := []float64{0.01, -0.02, 0.005, 0.03}
+	volume := []float64{1e6, 2e6, 0., 3e6}
+	m := MovAmihud(returns, volume, 2, WindowOpts{Trailing: true, FullWindow: true})
+	for i := 0; i < len(m); i++ {
+		m[i] *= 1e6
+	}
+	compareArrays([]float64{0.01, 0.01, 0.01}, m, t)
+}
@@ -0,0 +1,74 @@
+package gostat
+
+import (
+	"github.com/gonum/matrix/mat64"
+	"github.com/gonum/stat/distuv"
+	"math"
+)
+
+// MahalanobisDistance returns the Mahalanobis distance of each observation
+// of aligned series, where series[j][i] is the i-th observation of the j-th
+// series, from the mean of the series. Distances are measured under the
+// covariance matrix cov, or the sample covariance matrix of the series if
+// cov is nil.
+func MahalanobisDistance(series [][]float64, cov [][]float64) ([]float64, error) {
+	if err := checkPanel(series, 2); err != nil {
+		return nil, err
+	}
+	mean, sample := meanCov(series, nil)
+	if cov != nil {
+		if len(cov) != len(series) {
+			return nil, ErrLengthMismatch
+		}
+		sample = slicesToSym(cov)
+	}
+	return mahalanobis(series, mean, sample)
+}
+
+// MahalanobisOutliers returns the Mahalanobis distances of the observations
+// of aligned series from their center, and the indices of the observations
+// whose squared distance exceeds the 1-alpha quantile of the chi-square
+// distribution with as many degrees of freedom as there are series.
+//
+// With robust set the center and the covariance are the minimum covariance
+// determinant estimates from RobustCovariance, drawing from the source set
+// by SetSource, otherwise the mean and the sample covariance, which are
+// themselves distorted by the outliers.
+func MahalanobisOutliers(series [][]float64, alpha float64, robust bool) ([]float64, []int, error) {
+	if !(alpha > 0 && alpha < 1) {
+		return nil, nil, ErrInvalidProbability
+	}
+	if err := checkPanel(series, 2); err != nil {
+		return nil, nil, err
+	}
+
+	center, cov := meanCov(series, nil)
+	if robust {
+		mcd, ok := RobustCovariance(series, nil)
+		if !ok {
+			return nil, nil, ErrSingularMatrix
+		}
+		center, cov = mcd.Location, slicesToSym(mcd.Covariance)
+	}
+	dist, err := mahalanobis(series, center, cov)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	cutoff := math.Sqrt(distuv.ChiSquared{K: float64(len(series))}.Quantile(1 - alpha))
+	return dist, outliers(dist, func(d float64) bool {
+		return d > cutoff
+	}), nil
+}
+
+func mahalanobis(series [][]float64, center []float64, cov *mat64.SymDense) ([]float64, error) {
+	var chol mat64.Cholesky
+	if !chol.Factorize(cov) {
+		return nil, ErrSingularMatrix
+	}
+	dist := mahalanobisSq(series, center, &chol)
+	for i := 0; i < len(dist); i++ {
+		dist[i] = math.Sqrt(dist[i])
+	}
+	return dist, nil
+}
@@ -0,0 +1,57 @@
+package gostat
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func TestMahalanobisDistance(t *testing.T) {
+	x := []float64{1., -1., 1., -1.}
+	y := []float64{3., 3., -3., -3.}
+	dist, err := MahalanobisDistance([][]float64{x, y}, nil)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	// variances are 4/3 and 12, every observation is one sd away in both
+	d := math.Sqrt(1./(4./3.) + 9./12.)
+	compareArrays([]float64{d, d, d, d}, dist, t)
+}
+
+func TestMahalanobisDistance_Covariance(t *testing.T) {
+	x := []float64{1., -1., 1., -1.}
+	y := []float64{3., 3., -3., -3.}
+	cov := [][]float64{{1., 0.}, {0., 9.}}
+	dist, err := MahalanobisDistance([][]float64{x, y}, cov)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	compareArrays([]float64{math.Sqrt2, math.Sqrt2, math.Sqrt2, math.Sqrt2}, dist, t)
+
+	singular := [][]float64{{1., 1.}, {1., 1.}}
+	if _, err := MahalanobisDistance([][]float64{x, y}, singular); err != ErrSingularMatrix {
+		t.Errorf("Expected error=%v, got=%v", ErrSingularMatrix, err)
+	}
+}
+
+func TestMahalanobisOutliers(t *testing.T) {
+	rnd := rand.New(rand.NewSource(7))
+	n := 200
+	x, y := make([]float64, n), make([]float64, n)
+	for i := 0; i < n; i++ {
+		x[i] = rnd.NormFloat64()
+		y[i] = 0.9*x[i] + 0.3*rnd.NormFloat64()
+	}
+	// jointly unusual, although unremarkable for each series alone
+	x[100], y[100] = 1.5, -1.5
+
+	_, idx, err := MahalanobisOutliers([][]float64{x, y}, 0.001, true)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	compareIndices([]int{100}, idx, t)
+
+	if _, _, err := MahalanobisOutliers([][]float64{x, y}, 1.5, false); err != ErrInvalidProbability {
+		t.Errorf("Expected error=%v, got=%v", ErrInvalidProbability, err)
+	}
+}
@@ -0,0 +1,124 @@
+package gostat
+
+import (
+	"github.com/gonum/matrix/mat64"
+	"math"
+)
+
+// checkSeries panics unless all series have the same length, which it
+// returns.
+func checkSeries(series [][]float64) int {
+	if len(series) == 0 {
+		return 0
+	}
+	n := len(series[0])
+	for j := 1; j < len(series); j++ {
+		if len(series[j]) != n {
+			panic("gostat: slice length mismatch")
+		}
+	}
+	return n
+}
+
+// meanCov returns the mean and the sample covariance matrix of the aligned
+// series, using only the observations at the indices listed in idx, or all
+// observations if idx is nil.
+func meanCov(series [][]float64, idx []int) ([]float64, *mat64.SymDense) {
+	p := len(series)
+	if idx == nil {
+		idx = make([]int, checkSeries(series))
+		for i := 0; i < len(idx); i++ {
+			idx[i] = i
+		}
+	}
+	m := float64(len(idx))
+
+	mean := make([]float64, p)
+	for j := 0; j < p; j++ {
+		for _, i := range idx {
+			mean[j] += series[j][i]
+		}
+		mean[j] /= m
+	}
+
+	cov := mat64.NewSymDense(p, nil)
+	for a := 0; a < p; a++ {
+		for b := a; b < p; b++ {
+			var sum neumaierSum
+			for _, i := range idx {
+				sum.add((series[a][i] - mean[a]) * (series[b][i] - mean[b]))
+			}
+			cov.SetSym(a, b, sum.total()/(m-1))
+		}
+	}
+	return mean, cov
+}
+
+// mahalanobisSq returns the squared Mahalanobis distances of all the
+// observations of series from center under the covariance matrix factorized
+// in chol.
+func mahalanobisSq(series [][]float64, center []float64, chol *mat64.Cholesky) []float64 {
+	p := len(series)
+	n := checkSeries(series)
+	d := make([]float64, n)
+	diff := mat64.NewVector(p, nil)
+	var z mat64.Vector
+	for i := 0; i < n; i++ {
+		for j := 0; j < p; j++ {
+			diff.SetVec(j, series[j][i]-center[j])
+		}
+		if err := z.SolveCholeskyVec(chol, diff); err != nil {
+			d[i] = math.NaN()
+			continue
+		}
+		d[i] = mat64.Dot(diff, &z)
+	}
+	return d
+}
+
+// symToSlices returns the elements of a symmetric matrix as a slice of rows.
+func symToSlices(s *mat64.SymDense) [][]float64 {
+	n := s.Symmetric()
+	rows := make([][]float64, n)
+	for i := 0; i < n; i++ {
+		rows[i] = make([]float64, n)
+		for j := 0; j < n; j++ {
+			rows[i][j] = s.At(i, j)
+		}
+	}
+	return rows
+}
+
+// slicesToSym returns a symmetric matrix from the upper triangle of a slice
+// of rows.
+func slicesToSym(rows [][]float64) *mat64.SymDense {
+	s := mat64.NewSymDense(len(rows), nil)
+	for i := 0; i < len(rows); i++ {
+		for j := i; j < len(rows); j++ {
+			s.SetSym(i, j, rows[i][j])
+		}
+	}
+	return s
+}
+
+// covToCorr returns the correlation matrix matching covariance matrix cov.
+func covToCorr(cov [][]float64) [][]float64 {
+	corr := make([][]float64, len(cov))
+	for i := 0; i < len(cov); i++ {
+		corr[i] = make([]float64, len(cov))
+		for j := 0; j < len(cov); j++ {
+			corr[i][j] = cov[i][j] / math.Sqrt(cov[i][i]*cov[j][j])
+		}
+	}
+	return corr
+}
+
+// byValue sorts indices by the values they refer to.
+type byValue struct {
+	idx    []int
+	values []float64
+}
+
+func (b byValue) Len() int           { return len(b.idx) }
+func (b byValue) Less(i, j int) bool { return b.values[b.idx[i]] < b.values[b.idx[j]] }
+func (b byValue) Swap(i, j int)      { b.idx[i], b.idx[j] = b.idx[j], b.idx[i] }
@@ -0,0 +1,193 @@
+package gostat
+
+import (
+	"github.com/gonum/matrix/mat64"
+	"github.com/gonum/stat/distuv"
+	"math/rand"
+	"sort"
+)
+
+// MCD is a robust estimate of the location and scatter of aligned series,
+// the minimum covariance determinant (MCD) estimator.
+type MCD struct {
+	// Location is the robust mean of each series.
+	Location []float64
+	// Covariance is the robust covariance matrix of the series.
+	Covariance [][]float64
+	// Support lists the indices of the observations used for the estimate.
+	Support []int
+}
+
+const (
+	mcdStarts     = 500
+	mcdCandidates = 10
+	mcdMaxSteps   = 100
+)
+
+// RobustCovariance returns the minimum covariance determinant estimate of
+// the location and covariance of aligned series, where series[j][i] is the
+// i-th observation of the j-th series.
+//
+// The MCD looks for the half of the observations whose covariance matrix has
+// the lowest determinant, so a few outlying observations cannot dominate
+// the estimate the way they do with the sample covariance. It is computed
+// with the FastMCD algorithm:
+//
+// 1. the covariance of many random subsets of p+1 observations, where p is
+// the number of series, is refined by concentration steps, each keeping the
+// h = (n+p+1)/2 observations with the smallest Mahalanobis distances;
+//
+// 2. the best candidates are iterated until the determinant converges;
+//
+// 3. the covariance is scaled to be consistent at the normal distribution;
+//
+// 4. the estimate is reweighted using the observations within the 97.5%
+// chi-square quantile of the distances.
+//
+// It returns false unless there are at least two more observations than
+// series, so that the subsets of p+1 observations can differ, or if the
+// observations lie on a hyperplane so that the covariance is singular.
+// FastMCD is intended for small numbers of series. The random subsets are
+// drawn from src, or from the source set by SetSource when src is nil.
+func RobustCovariance(series [][]float64, src rand.Source) (MCD, bool) {
+	p := len(series)
+	n := checkSeries(series)
+	if p == 0 || n <= p+1 {
+		return MCD{}, false
+	}
+	h := (n + p + 1) / 2
+	rnd := rand.New(sourceOr(src))
+
+	var candidates []mcdCandidate
+	for s := 0; s < mcdStarts; s++ {
+		c, ok := mcdStart(series, h, rnd)
+		if !ok {
+			continue
+		}
+		for i := 0; i < 2 && ok; i++ {
+			c, ok = c.step(series, h)
+		}
+		if ok {
+			candidates = append(candidates, c)
+		}
+	}
+	if len(candidates) == 0 {
+		return MCD{}, false
+	}
+	sort.Sort(byDet(candidates))
+	if len(candidates) > mcdCandidates {
+		candidates = candidates[:mcdCandidates]
+	}
+
+	best := candidates[0]
+	for _, c := range candidates {
+		for i := 0; i < mcdMaxSteps; i++ {
+			next, ok := c.step(series, h)
+			if !ok || next.det >= c.det {
+				break
+			}
+			c = next
+		}
+		if c.det < best.det {
+			best = c
+		}
+	}
+
+	chi2 := distuv.ChiSquared{K: float64(p)}
+	mean, cov, ok := mcdConsistent(series, best.idx, chi2)
+	if !ok {
+		return MCD{}, false
+	}
+	var chol mat64.Cholesky
+	if !chol.Factorize(cov) {
+		return MCD{}, false
+	}
+	var support []int
+	cutoff := chi2.Quantile(0.975)
+	for i, d := range mahalanobisSq(series, mean, &chol) {
+		if d <= cutoff {
+			support = append(support, i)
+		}
+	}
+	if mean, cov, ok = mcdConsistent(series, support, chi2); !ok {
+		return MCD{}, false
+	}
+
+	return MCD{Location: mean, Covariance: symToSlices(cov), Support: support}, true
+}
+
+// RobustCorrelation returns the correlation matrix of aligned series derived
+// from their minimum covariance determinant estimate, see RobustCovariance.
+func RobustCorrelation(series [][]float64, src rand.Source) ([][]float64, bool) {
+	mcd, ok := RobustCovariance(series, src)
+	if !ok {
+		return nil, false
+	}
+	return covToCorr(mcd.Covariance), true
+}
+
+// mcdCandidate is a subset of h observations considered by FastMCD.
+type mcdCandidate struct {
+	idx  []int
+	mean []float64
+	chol mat64.Cholesky
+	det  float64
+}
+
+type byDet []mcdCandidate
+
+func (c byDet) Len() int           { return len(c) }
+func (c byDet) Less(i, j int) bool { return c[i].det < c[j].det }
+func (c byDet) Swap(i, j int)      { c[i], c[j] = c[j], c[i] }
+
+// mcdStart returns a candidate from a random subset of p+1 observations,
+// extended with further random observations until its covariance matrix is
+// not singular.
+func mcdStart(series [][]float64, h int, rnd *rand.Rand) (mcdCandidate, bool) {
+	perm := rnd.Perm(checkSeries(series))
+	for m := len(series) + 1; m <= h; m++ {
+		if c, ok := newMCDCandidate(series, perm[:m]); ok {
+			return c.step(series, h)
+		}
+	}
+	return mcdCandidate{}, false
+}
+
+func newMCDCandidate(series [][]float64, idx []int) (mcdCandidate, bool) {
+	c := mcdCandidate{idx: idx}
+	var cov *mat64.SymDense
+	c.mean, cov = meanCov(series, idx)
+	if !c.chol.Factorize(cov) {
+		return c, false
+	}
+	c.det = c.chol.Det()
+	return c, c.det > 0
+}
+
+// step performs a concentration step, returning the candidate made of the h
+// observations closest to c.
+func (c *mcdCandidate) step(series [][]float64, h int) (mcdCandidate, bool) {
+	d := mahalanobisSq(series, c.mean, &c.chol)
+	idx := make([]int, len(d))
+	for i := 0; i < len(idx); i++ {
+		idx[i] = i
+	}
+	sort.Sort(byValue{idx, d})
+	idx = idx[:h]
+	sort.Ints(idx)
+	return newMCDCandidate(series, idx)
+}
+
+// mcdConsistent returns the mean and covariance of the observations listed
+// in idx, with the covariance scaled so that the median squared distance of
+// all observations matches the median of the chi-square distribution.
+func mcdConsistent(series [][]float64, idx []int, chi2 distuv.ChiSquared) ([]float64, *mat64.SymDense, bool) {
+	mean, cov := meanCov(series, idx)
+	var chol mat64.Cholesky
+	if !chol.Factorize(cov) {
+		return nil, nil, false
+	}
+	factor := Median(mahalanobisSq(series, mean, &chol)) / chi2.Quantile(0.5)
+	cov.ScaleSym(factor, cov)
+	return mean, cov, true
+}
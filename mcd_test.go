@@ -0,0 +1,93 @@
+package gostat
+
+import (
+	"github.com/gonum/stat"
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func TestRobustCovariance(t *testing.T) {
+	rnd := rand.New(rand.NewSource(42))
+	n := 200
+	x, y := make([]float64, n), make([]float64, n)
+	for i := 0; i < n; i++ {
+		x[i] = rnd.NormFloat64()
+		y[i] = 0.8*x[i] + 0.6*rnd.NormFloat64()
+	}
+	// a few bad ticks moving against each other
+	for _, i := range []int{10, 50, 90, 130, 170} {
+		x[i], y[i] = 8., -8.
+	}
+
+	if got := stat.Correlation(x, y, nil); got > 0.5 {
+		t.Fatalf("Expected outliers to distort sample correlation, got=%f", got)
+	}
+	mcd, ok := RobustCovariance([][]float64{x, y}, nil)
+	if !ok {
+		t.Fatalf("Expected MCD estimate")
+	}
+	corr := mcd.Covariance[0][1] / math.Sqrt(mcd.Covariance[0][0]*mcd.Covariance[1][1])
+	if got, want := corr, 0.8; math.Abs(got-want) > 0.1 {
+		t.Errorf("Expected robust correlation=%f, got=%f", want, got)
+	}
+	for _, i := range mcd.Support {
+		if x[i] == 8. {
+			t.Errorf("Expected outlier at index %d outside of support", i)
+		}
+	}
+	for j := 0; j < 2; j++ {
+		if got := mcd.Location[j]; math.Abs(got) > 0.25 {
+			t.Errorf("Expected location near zero, got=%f", got)
+		}
+	}
+
+	rcorr, ok := RobustCorrelation([][]float64{x, y}, nil)
+	if !ok {
+		t.Fatalf("Expected MCD estimate")
+	}
+	if got, want := rcorr[1][0], corr; !floatEquals(got, want) {
+		t.Errorf("Expected robust correlation=%f, got=%f", want, got)
+	}
+	if got, want := rcorr[0][0], 1.; !floatEquals(got, want) {
+		t.Errorf("Expected correlation=%f, got=%f", want, got)
+	}
+}
+
+func TestRobustCovariance_TooFewObservations(t *testing.T) {
+	if _, ok := RobustCovariance([][]float64{{1., 2., 3.}, {2., 1., 3.}}, nil); ok {
+		t.Errorf("Expected no MCD estimate")
+	}
+}
+
+func TestRobustCovariance_Singular(t *testing.T) {
+	x := []float64{1., 2., 3., 4., 5., 6., 7., 8.}
+	y := []float64{2., 4., 6., 8., 10., 12., 14., 16.}
+	if _, ok := RobustCovariance([][]float64{x, y}, nil); ok {
+		t.Errorf("Expected no MCD estimate")
+	}
+}
+
+func TestRobustCovariance_Source(t *testing.T) {
+	defer SetSource(nil)
+	rnd := rand.New(rand.NewSource(5))
+	series := [][]float64{make([]float64, 60), make([]float64, 60)}
+	for i := 0; i < 60; i++ {
+		series[0][i] = rnd.NormFloat64()
+		series[1][i] = series[0][i] + rnd.NormFloat64()
+	}
+	series[0][7], series[1][7] = 6., -6.
+
+	SetSource(WithSeed(3))
+	want, ok := RobustCovariance(series, WithSeed(9))
+	if !ok {
+		t.Fatalf("Expected MCD estimate")
+	}
+	// draws from the shared source do not affect a call with its own
+	RobustCovariance(series, nil)
+	got, _ := RobustCovariance(series, WithSeed(9))
+	compareIndices(want.Support, got.Support, t)
+	for j := 0; j < 2; j++ {
+		compareArrays(want.Covariance[j], got.Covariance[j], t)
+	}
+}
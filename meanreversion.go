@@ -0,0 +1,112 @@
+package gostat
+
+import (
+	"math"
+	"math/rand"
+)
+
+// MeanReversionHalfLife returns the half-life of mean reversion of x in
+// periods, the time it takes a deviation from the long run mean to halve,
+// estimated from the AR(1) coefficient phi of the series, -ln(2)/ln(phi),
+// such as how long the spread of a cointegrated pair takes to close half
+// its gap. It is +Inf when the series does not revert to a mean, phi of 1
+// or more, and 0 when it reverts within a period, phi of 0 or less. It is
+// NaN when x has fewer than three values or NaN values.
+func MeanReversionHalfLife(x []float64) float64 {
+	_, phi, _, err := fitAR1(x)
+	switch {
+	case err != nil:
+		return math.NaN()
+	case phi >= 1:
+		return math.Inf(1)
+	case phi <= 0:
+		return 0
+	}
+	return -math.Ln2 / math.Log(phi)
+}
+
+// OU holds the parameters of an Ornstein-Uhlenbeck process,
+// dx = Theta*(Mu-x)*dt + Sigma*dW, a continuous time model of a series
+// pulled back to its long run mean Mu at the rate Theta, such as an interest
+// rate or the spread of a pair of assets.
+type OU struct {
+	Theta, Mu, Sigma float64
+}
+
+// HalfLife returns the half-life of mean reversion of the process, ln(2)/Theta.
+func (p OU) HalfLife() float64 {
+	return math.Ln2 / p.Theta
+}
+
+// FitOU estimates the parameters of an Ornstein-Uhlenbeck process from the
+// series x observed every dt units of time, such as 1/252 for daily
+// observations with Theta and Sigma per year, by maximum likelihood of the
+// exact discretization of the process, an AR(1) with coefficient
+// exp(-Theta*dt). ErrEmptyInput is returned for fewer than three values,
+// ErrNaNInput for NaN values, ErrInvalidParameter for dt that is not
+// positive, and ErrNotConverged when the series does not revert to a mean,
+// so that it has no stationary OU fit.
+func FitOU(x []float64, dt float64) (OU, error) {
+	if !(dt > 0) {
+		return OU{}, ErrInvalidParameter
+	}
+	a, phi, resid, err := fitAR1(x)
+	if err != nil {
+		return OU{}, err
+	}
+	if !(phi > 0 && phi < 1) {
+		return OU{}, ErrNotConverged
+	}
+	var ss neumaierSum
+	for i := 0; i < len(resid); i++ {
+		ss.add(resid[i] * resid[i])
+	}
+	theta := -math.Log(phi) / dt
+	variance := ss.total() / float64(len(resid))
+	return OU{
+		Theta: theta,
+		Mu:    a / (1 - phi),
+		Sigma: math.Sqrt(variance * 2 * theta / (1 - phi*phi)),
+	}, nil
+}
+
+// SimulateOU returns n values of the Ornstein-Uhlenbeck process p observed
+// every dt units of time starting from x0, which is the first value, drawn
+// from its exact transition distribution so that the path is correct for any
+// dt. The random numbers are drawn from src, or from the source set by
+// SetSource when src is nil.
+func SimulateOU(p OU, x0, dt float64, n int, src rand.Source) []float64 {
+	if n < 1 {
+		return []float64{}
+	}
+	rng := rand.New(sourceOr(src))
+	decay := math.Exp(-p.Theta * dt)
+	sd := p.Sigma * math.Sqrt((1-decay*decay)/(2*p.Theta))
+	path := make([]float64, n)
+	path[0] = x0
+	for i := 1; i < n; i++ {
+		path[i] = p.Mu + (path[i-1]-p.Mu)*decay + sd*rng.NormFloat64()
+	}
+	return path
+}
+
+// fitAR1 returns the intercept and the coefficient of the least squares
+// regression of each value of x on the previous one, with the residuals.
+func fitAR1(x []float64) (float64, float64, []float64, error) {
+	if len(x) < 3 {
+		return 0, 0, nil, ErrEmptyInput
+	}
+	if hasNaN(x) {
+		return 0, 0, nil, ErrNaNInput
+	}
+	prev, next := x[:len(x)-1], x[1:]
+	coef, err := leastSquares(next, [][]float64{prev}, nil)
+	if err != nil {
+		return 0, 0, nil, err
+	}
+	resid := make([]float64, len(next))
+	for i := 0; i < len(next); i++ {
+		resid[i] = next[i] - coef[0] - coef[1]*prev[i]
+	}
+	return coef[0], coef[1], resid, nil
+}
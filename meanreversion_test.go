@@ -0,0 +1,68 @@
+package gostat
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func TestMeanReversionHalfLife(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	x := make([]float64, 5000)
+	for i := 1; i < len(x); i++ {
+		x[i] = 0.9*x[i-1] + rnd.NormFloat64()
+	}
+	if got, want := MeanReversionHalfLife(x), -math.Ln2/math.Log(0.9); math.Abs(got-want) > 0.5 {
+		t.Errorf("Expected half-life near %f, got=%f", want, got)
+	}
+
+	trend := []float64{1., 2., 4., 8., 16., 32.}
+	if got := MeanReversionHalfLife(trend); !math.IsInf(got, 1) {
+		t.Errorf("Expected half-life=+Inf, got=%f", got)
+	}
+	if got := MeanReversionHalfLife([]float64{1., -1., 1., -1.5, 1.}); got != 0 {
+		t.Errorf("Expected half-life=0, got=%f", got)
+	}
+	if got := MeanReversionHalfLife([]float64{1., 2.}); !math.IsNaN(got) {
+		t.Errorf("Expected half-life=NaN, got=%f", got)
+	}
+}
+
+func TestFitOU(t *testing.T) {
+	want := OU{Theta: 5., Mu: 0.03, Sigma: 0.01}
+	x := SimulateOU(want, 0.05, 1./252, 20000, rand.NewSource(7))
+	if got := x[0]; got != 0.05 {
+		t.Errorf("Expected path to start at 0.05, got=%f", got)
+	}
+	got, err := FitOU(x, 1./252)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if got.Theta < 4 || got.Theta > 6.5 {
+		t.Errorf("Expected theta near %f, got=%f", want.Theta, got.Theta)
+	}
+	if math.Abs(got.Mu-want.Mu) > 0.001 {
+		t.Errorf("Expected mu near %f, got=%f", want.Mu, got.Mu)
+	}
+	if math.Abs(got.Sigma-want.Sigma) > 0.0005 {
+		t.Errorf("Expected sigma near %f, got=%f", want.Sigma, got.Sigma)
+	}
+	if hl, want := got.HalfLife()*252, MeanReversionHalfLife(x); !floatEquals(hl, want) {
+		t.Errorf("Expected half-life=%f, got=%f", want, hl)
+	}
+}
+
+func TestFitOU_Errors(t *testing.T) {
+	if _, err := FitOU([]float64{1., 2., 3.}, 0); err != ErrInvalidParameter {
+		t.Errorf("Expected error=%v, got=%v", ErrInvalidParameter, err)
+	}
+	if _, err := FitOU([]float64{1., 2.}, 1); err != ErrEmptyInput {
+		t.Errorf("Expected error=%v, got=%v", ErrEmptyInput, err)
+	}
+	if _, err := FitOU([]float64{1., 2., 4., 8., 16.}, 1); err != ErrNotConverged {
+		t.Errorf("Expected error=%v, got=%v", ErrNotConverged, err)
+	}
+	if got := SimulateOU(OU{Theta: 1, Sigma: 1}, 0, 1, 0, nil); len(got) != 0 {
+		t.Errorf("Expected empty path, got=%v", got)
+	}
+}
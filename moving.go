@@ -0,0 +1,271 @@
+package gostat
+
+import (
+	"math"
+)
+
+// WindowOpts controls how a series is split into sliding windows by the
+// moving statistics functions.
+type WindowOpts struct {
+	// Weights of the elements in a window, nil for equal weights. When set
+	// it must hold k values; windows truncated at the endpoints use the part
+	// of the weights matching their elements.
+	Weights []float64
+	// OmitNaNs omits NaN and infinite values before splitting the series.
+	OmitNaNs bool
+	// Trailing selects trailing windows instead of center windows.
+	Trailing bool
+	// FullWindow discards any window that uses fewer elements than k.
+	FullWindow bool
+	// NaNPolicy selects how NaN values are handled. Unlike OmitNaNs, which
+	// removes NaN values before splitting the series, NaNSkip keeps the
+	// windows aligned with x.
+	NaNPolicy NaNPolicy
+	// Align selects the position of each window relative to the element it
+	// is anchored at. AlignRight is the same as Trailing.
+	Align Alignment
+	// Variance selects the method used by MovVariance and MovStdDevWith.
+	Variance VarianceMethod
+	// Pad aligns the output with x, so that its i-th value is calculated
+	// over the window anchored at x[i]. The output then always has len(x)
+	// values, which are NaN for windows discarded by FullWindow and for
+	// values omitted by OmitNaNs.
+	Pad bool
+}
+
+// Alignment is the position of a sliding window relative to the element
+// it is anchored at.
+type Alignment int
+
+const (
+	// AlignCenter centers the window on the element, with one more
+	// element before it than after it for windows of even length.
+	AlignCenter Alignment = iota
+	// AlignRight ends the window at the element, so that it only covers
+	// the element and the ones before it.
+	AlignRight
+	// AlignLeft starts the window at the element, so that it only covers
+	// the element and the ones after it.
+	AlignLeft
+)
+
+func (opts WindowOpts) alignment() Alignment {
+	if opts.Trailing {
+		return AlignRight
+	}
+	return opts.Align
+}
+
+// MovApply returns a slice of local k-point statistics, where each value is
+// calculated by fn over a sliding window of length k across neighboring
+// elements of x. The windows are selected the same way as by RollingWindow
+// and fn receives the weights for each window, nil if opts has no weights.
+// With Pad set the value is NaN where there is no window.
+//
+// With NaNSkip the NaN values and their weights are removed from each
+// window before calling fn, which must not retain the slices it receives.
+func MovApply(x []float64, k int, opts WindowOpts, fn func(window, weights []float64) float64) []float64 {
+	var rets []float64
+	movEach(x, k, opts, func(n int) {
+		rets = make([]float64, 0, n)
+	}, func(window, weights []float64) {
+		rets = append(rets, fn(window, weights))
+	}, func() {
+		rets = append(rets, math.NaN())
+	})
+	return rets
+}
+
+// MovApplyMulti returns m slices of local k-point statistics calculated
+// together by fn over each sliding window, selected the same way as by
+// MovApply, so that related statistics such as a mean and a standard
+// deviation or a slope and an intercept share a single pass over the
+// windows. For each window fn receives dst of length m, where it stores the
+// m values, any value it does not store being NaN, and the j-th slice
+// returned holds the j-th value of every window.
+func MovApplyMulti(x []float64, k int, opts WindowOpts, m int, fn func(window, weights, dst []float64)) [][]float64 {
+	rets := make([][]float64, m)
+	dst := make([]float64, m)
+	movEach(x, k, opts, func(n int) {
+		for j := 0; j < m; j++ {
+			rets[j] = make([]float64, 0, n)
+		}
+	}, func(window, weights []float64) {
+		for j := 0; j < m; j++ {
+			dst[j] = math.NaN()
+		}
+		fn(window, weights, dst)
+		for j := 0; j < m; j++ {
+			rets[j] = append(rets[j], dst[j])
+		}
+	}, func() {
+		for j := 0; j < m; j++ {
+			rets[j] = append(rets[j], math.NaN())
+		}
+	})
+	return rets
+}
+
+// MovApplyAligned returns a slice of local k-point statistics of several
+// aligned series, where each value is calculated by fn over the windows of
+// all the series selected by NewAlignedWindowIter, such as a rolling
+// regression of one series on the others. fn receives the window of each
+// series and their weights, and must not retain the slices it receives.
+// With Pad set the value is NaN where there is no window. It panics if the
+// series do not have the same length.
+func MovApplyAligned(series [][]float64, k int, opts WindowOpts, fn func(windows [][]float64, weights []float64) float64) []float64 {
+	it := NewAlignedWindowIter(series, k, opts)
+	rets := make([]float64, 0, it.Len())
+	for it.Next() {
+		if it.Padded() {
+			rets = append(rets, math.NaN())
+			continue
+		}
+		rets = append(rets, fn(it.Windows(), it.Weights()))
+	}
+	return rets
+}
+
+// RollingBy returns local k-point statistics calculated by fn, as by
+// MovApply, independently within each group of the values of x sharing the
+// same key, such as the rolling volatility of each symbol in a combined
+// slice of returns. The windows of a group only contain its own values, in
+// their order in x, and the output is aligned with x, padded as if Pad was
+// set in opts.
+func RollingBy(keys []string, x []float64, k int, opts WindowOpts, fn func(window, weights []float64) float64) []float64 {
+	if len(keys) != len(x) {
+		panic("gostat: slice length mismatch")
+	}
+	groups := make(map[string][]int)
+	var order []string
+	for i := 0; i < len(keys); i++ {
+		if _, ok := groups[keys[i]]; !ok {
+			order = append(order, keys[i])
+		}
+		groups[keys[i]] = append(groups[keys[i]], i)
+	}
+	opts.Pad = true
+	rets := make([]float64, len(x))
+	var v []float64
+	for _, key := range order {
+		idx := groups[key]
+		v = v[:0]
+		for _, i := range idx {
+			v = append(v, x[i])
+		}
+		stats := MovApply(v, k, opts, fn)
+		for j, i := range idx {
+			rets[i] = stats[j]
+		}
+	}
+	return rets
+}
+
+// movEach calls fn for each window selected by opts, as described by
+// MovApply, after calling init with the number of windows. It calls pad
+// instead of fn for the elements without a window in padded output.
+func movEach(x []float64, k int, opts WindowOpts, init func(n int), fn func(window, weights []float64), pad func()) {
+	v := windowSeries(x, opts)
+	it := windowIterFor(x, v, k, opts)
+	init(it.len())
+	var bufV, bufW []float64
+	for it.next() {
+		if it.pad {
+			pad()
+			continue
+		}
+		window := v[it.start:it.end]
+		var weights []float64
+		if opts.Weights != nil {
+			weights = opts.Weights[it.off : it.off+len(window)]
+		}
+		if opts.NaNPolicy == NaNSkip && hasNaN(window) {
+			bufV, bufW = bufV[:0], bufW[:0]
+			for i := 0; i < len(window); i++ {
+				if math.IsNaN(window[i]) {
+					continue
+				}
+				bufV = append(bufV, window[i])
+				if weights != nil {
+					bufW = append(bufW, weights[i])
+				}
+			}
+			window = bufV
+			if weights != nil {
+				weights = bufW
+			}
+		}
+		fn(window, weights)
+	}
+}
+
+// windowSeries returns the series to split into windows, with NaN values
+// omitted or interpolated as selected by opts.
+func windowSeries(x []float64, opts WindowOpts) []float64 {
+	if opts.OmitNaNs {
+		return filterNaNs(x)
+	}
+	if opts.NaNPolicy == NaNInterpolate {
+		if v := interpolateNaNs(x); v != nil {
+			return v
+		}
+	}
+	return x
+}
+
+// MovMean returns moving mean, a slice of local k-point mean values.
+// Without weights the mean is updated incrementally in O(n) time.
+func MovMean(x []float64, k int, opts WindowOpts) []float64 {
+	if opts.Weights == nil {
+		return movAccumulate(x, k, opts, &sumAcc{mean: true})
+	}
+	return MovApply(x, k, opts, Mean)
+}
+
+// MovMedian returns moving median, a slice of local k-point median values.
+// When weights are set each value is the WeightedMedian of the window,
+// otherwise the median is updated incrementally over an order statistic
+// tree of the window in O(n log k) time, and the median of a window
+// containing NaN is NaN.
+func MovMedian(x []float64, k int, opts WindowOpts) []float64 {
+	if opts.Weights != nil {
+		return MovApply(x, k, opts, WeightedMedian)
+	}
+	return movAccumulate(x, k, opts, newMedianAcc(k))
+}
+
+// MovMAD returns moving median absolute deviation, a slice of local k-point
+// MAD values. When weights are set each value is the WeightedMAD of the
+// window, otherwise the window is kept in the same order statistic tree as
+// by MovMedian and the MAD is selected from it in O(n log^2 k) time, and
+// the MAD of a window containing NaN, or of an empty window, is NaN.
+func MovMAD(x []float64, k int, opts WindowOpts) []float64 {
+	if opts.Weights != nil {
+		return MovApply(x, k, opts, WeightedMAD)
+	}
+	return movAccumulate(x, k, opts, newMADAcc(k))
+}
+
+// MovMin returns moving minimum, a slice of local k-point minimum values.
+// Weights are ignored. The minimum is tracked in a monotonic deque in O(n)
+// time, and the minimum of a window containing NaN is NaN.
+func MovMin(x []float64, k int, opts WindowOpts) []float64 {
+	return movAccumulate(x, k, opts, &extremumAcc{sign: -1})
+}
+
+// MovMax returns moving maximum, a slice of local k-point maximum values.
+// Weights are ignored. The maximum is tracked in a monotonic deque in O(n)
+// time, and the maximum of a window containing NaN is NaN.
+func MovMax(x []float64, k int, opts WindowOpts) []float64 {
+	return movAccumulate(x, k, opts, &extremumAcc{sign: 1})
+}
+
+// MovSum returns moving sum, a slice of local k-point sums. When weights are
+// set each element is multiplied by its weight, otherwise the sum is updated
+// incrementally in O(n) time.
+func MovSum(x []float64, k int, opts WindowOpts) []float64 {
+	if opts.Weights == nil {
+		return movAccumulate(x, k, opts, &sumAcc{})
+	}
+	return MovApply(x, k, opts, Dot)
+}
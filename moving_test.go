@@ -0,0 +1,184 @@
+package gostat
+
+import (
+	"github.com/gonum/stat"
+	"math"
+	"testing"
+)
+
+func TestMovApply(t *testing.T) {
+	x := []float64{1., 2., 3., 4., 5.}
+	m := MovApply(x, 3, WindowOpts{}, func(window, weights []float64) float64 {
+		if weights != nil {
+			t.Errorf("Expected nil weights, got=%v", weights)
+		}
+		return float64(len(window))
+	})
+	compareArrays([]float64{2., 3., 3., 3., 2.}, m, t)
+}
+
+func TestMovApply_Weights(t *testing.T) {
+	x := []float64{1., 2., 3., 4., 5.}
+	m := MovApply(x, 3, WindowOpts{Weights: []float64{1., 2., 3.}}, func(window, weights []float64) float64 {
+		if got, want := len(weights), len(window); got != want {
+			t.Fatalf("Expected number of weights=%d, got=%d", want, got)
+		}
+		return weights[0]
+	})
+	compareArrays([]float64{2., 1., 1., 1., 1.}, m, t)
+}
+
+func TestMovApply_WindowLongerThanSeries(t *testing.T) {
+	x := []float64{1., 2.}
+	m := MovApply(x, 5, WindowOpts{}, func(window, _ []float64) float64 {
+		return float64(len(window))
+	})
+	compareArrays([]float64{2., 2.}, m, t)
+}
+
+func TestMovApplyMulti(t *testing.T) {
+	x := []float64{4., 8., 6., -1., -2., -3., -1., 3., 4., 5.}
+	opts := WindowOpts{Trailing: true, FullWindow: true}
+	m := MovApplyMulti(x, 3, opts, 2, func(window, weights, dst []float64) {
+		dst[0], dst[1] = stat.MeanStdDev(window, weights)
+	})
+	if got, want := len(m), 2; got != want {
+		t.Fatalf("Expected number of outputs=%d, got=%d", want, got)
+	}
+	compareArrays(MovMean(x, 3, opts), m[0], t)
+	compareArrays(MovStdDev(x, nil, 3, false, true, true), m[1], t)
+}
+
+func TestMovApplyMulti_Unset(t *testing.T) {
+	x := []float64{1., 2., 3.}
+	m := MovApplyMulti(x, 2, WindowOpts{}, 2, func(window, _, dst []float64) {
+		dst[0] = float64(len(window))
+	})
+	compareArrays([]float64{1., 2., 2.}, m[0], t)
+	compareArrays([]float64{math.NaN(), math.NaN(), math.NaN()}, m[1], t)
+}
+
+func TestMovApplyAligned(t *testing.T) {
+	x := []float64{1., 2., 3., 4., 5.}
+	y := []float64{2., 1., 4., 3., 6.}
+	z := []float64{0., 1., 0., 1., 0.}
+	opts := WindowOpts{Trailing: true, Pad: true, FullWindow: true}
+	m := MovApplyAligned([][]float64{x, y, z}, 2, opts, func(windows [][]float64, _ []float64) float64 {
+		return Sum(windows[0]) + Sum(windows[1]) - Sum(windows[2])
+	})
+	compareArrays([]float64{math.NaN(), 5., 9., 13., 17.}, m, t)
+
+	beta := MovApplyAligned([][]float64{y, x}, 3, WindowOpts{}, func(windows [][]float64, weights []float64) float64 {
+		return stat.Covariance(windows[0], windows[1], weights) / stat.Variance(windows[1], weights)
+	})
+	compareArrays(MovBeta(y, x, 3, WindowOpts{}), beta, t)
+}
+
+func TestMovMean(t *testing.T) {
+	x := []float64{4., 8., 6., -1., -2., -3., -1., 3., 4., 5.}
+	m := MovMean(x, 3, WindowOpts{})
+	compareArrays([]float64{6., 6., 4.3333, 1., -2., -2., -0.3333, 2., 4., 4.5}, m, t)
+}
+
+func TestMovMean_Weighted(t *testing.T) {
+	x := []float64{1., 2., 3., 4., 5.}
+	m := MovMean(x, 3, WindowOpts{Weights: []float64{1., 2., 3.}})
+	compareArrays([]float64{1.6, 2.3333, 3.3333, 4.3333, 4.6667}, m, t)
+}
+
+func TestMovMean_TrailingWeighted(t *testing.T) {
+	x := []float64{1., 2., 3., 4.}
+	m := MovMean(x, 3, WindowOpts{Weights: []float64{1., 2., 3.}, Trailing: true})
+	compareArrays([]float64{1., 1.6, 2.3333, 3.3333}, m, t)
+}
+
+func TestMovMedian(t *testing.T) {
+	x := []float64{4., 8., 6., -1., -2., -3., -1., 3., 4., 5.}
+	m := MovMedian(x, 3, WindowOpts{})
+	compareArrays([]float64{6., 6., 6., -1., -2., -2., -1., 3., 4., 4.5}, m, t)
+}
+
+func TestMovMAD(t *testing.T) {
+	x := []float64{4., 8., 6., -1., -2., -3., -1., 3., 4., 5.}
+	m := MovMAD(x, 3, WindowOpts{})
+	compareArrays([]float64{2.9652, 2.9652, 2.9652, 1.4826, 1.4826, 1.4826, 2.9652, 1.4826, 1.4826, 0.7413}, m, t)
+}
+
+func TestMovMin(t *testing.T) {
+	x := []float64{4., 8., 6., -1., -2., -3., -1., 3., 4., 5.}
+	m := MovMin(x, 3, WindowOpts{})
+	compareArrays([]float64{4., 4., -1., -2., -3., -3., -3., -1., 3., 4.}, m, t)
+}
+
+func TestMovMax(t *testing.T) {
+	x := []float64{4., 8., 6., -1., -2., -3., -1., 3., 4., 5.}
+	m := MovMax(x, 3, WindowOpts{})
+	compareArrays([]float64{8., 8., 8., 6., -1., -1., 3., 4., 5., 5.}, m, t)
+}
+
+func TestMovMax_WithNaNs(t *testing.T) {
+	x := []float64{4., math.NaN(), 6., -1., -2.}
+	m := MovMax(x, 3, WindowOpts{Trailing: true})
+	compareArrays([]float64{4., math.NaN(), math.NaN(), math.NaN(), 6.}, m, t)
+}
+
+func TestMovSum(t *testing.T) {
+	x := []float64{4., 8., 6., -1., -2., -3., -1., 3., 4., 5.}
+	m := MovSum(x, 3, WindowOpts{FullWindow: true})
+	compareArrays([]float64{18., 13., 3., -6., -6., -1., 6., 12.}, m, t)
+}
+
+func TestMovMean_PadCenter(t *testing.T) {
+	x := []float64{1., 2., 3., 4., 5., 6.}
+	m := MovMean(x, 3, WindowOpts{Pad: true, FullWindow: true})
+	compareArrays([]float64{math.NaN(), 2., 3., 4., 5., math.NaN()}, m, t)
+	m = MovMean(x, 4, WindowOpts{Pad: true, FullWindow: true})
+	compareArrays([]float64{math.NaN(), math.NaN(), 2.5, 3.5, 4.5, math.NaN()}, m, t)
+	m = MovMean(x, 4, WindowOpts{Pad: true})
+	compareArrays(MovMean(x, 4, WindowOpts{}), m, t)
+}
+
+func TestMovSum_PadRight(t *testing.T) {
+	x := []float64{1., 2., 3., 4., 5.}
+	m := MovSum(x, 3, WindowOpts{Align: AlignRight, Pad: true, FullWindow: true})
+	compareArrays([]float64{math.NaN(), math.NaN(), 6., 9., 12.}, m, t)
+	compareArrays(MovSum(x, 3, WindowOpts{Trailing: true, Pad: true, FullWindow: true}), m, t)
+}
+
+func TestMovMean_AlignLeft(t *testing.T) {
+	x := []float64{1., 2., 3., 4., 5.}
+	m := MovMean(x, 3, WindowOpts{Align: AlignLeft})
+	compareArrays([]float64{2., 3., 4., 4.5, 5.}, m, t)
+	m = MovMean(x, 3, WindowOpts{Align: AlignLeft, FullWindow: true})
+	compareArrays([]float64{2., 3., 4.}, m, t)
+	m = MovMedian(x, 3, WindowOpts{Align: AlignLeft, Pad: true, FullWindow: true})
+	compareArrays([]float64{2., 3., 4., math.NaN(), math.NaN()}, m, t)
+}
+
+func TestMovApply_AlignLeftWeights(t *testing.T) {
+	x := []float64{1., 2., 3., 4., 5.}
+	m := MovApply(x, 3, WindowOpts{Weights: []float64{1., 2., 3.}, Align: AlignLeft}, func(window, weights []float64) float64 {
+		return weights[len(weights)-1]
+	})
+	compareArrays([]float64{3., 3., 3., 2., 1.}, m, t)
+}
+
+func TestMovMean_PadOmitNaNs(t *testing.T) {
+	x := []float64{1., math.NaN(), 3., 4., 5.}
+	m := MovMean(x, 2, WindowOpts{Trailing: true, OmitNaNs: true, Pad: true, FullWindow: true})
+	compareArrays([]float64{math.NaN(), math.NaN(), 2., 3.5, 4.5}, m, t)
+	m = MovApplyMulti(x, 2, WindowOpts{Trailing: true, OmitNaNs: true, Pad: true}, 1, func(window, _, dst []float64) {
+		dst[0] = float64(len(window))
+	})[0]
+	compareArrays([]float64{1., math.NaN(), 2., 2., 2.}, m, t)
+}
+
+func TestRollingBy(t *testing.T) {
+	keys := []string{"A", "B", "A", "B", "A", "B", "A"}
+	x := []float64{1., 10., 2., 20., 3., 30., 4.}
+	sum := func(window, _ []float64) float64 { return Sum(window) }
+	m := RollingBy(keys, x, 2, WindowOpts{Trailing: true, FullWindow: true}, sum)
+	compareArrays([]float64{math.NaN(), math.NaN(), 3., 30., 5., 50., 7.}, m, t)
+	m = RollingBy(keys, x, 2, WindowOpts{Trailing: true}, sum)
+	compareArrays([]float64{1., 10., 3., 30., 5., 50., 7.}, m, t)
+}
@@ -0,0 +1,195 @@
+package gostat
+
+import (
+	"math"
+)
+
+// NaNPolicy selects how NaN values in the input of a statistic are handled.
+// Infinite values are not affected by the policy.
+type NaNPolicy int
+
+const (
+	// NaNPropagate uses NaN values like any other value, so they propagate
+	// to the results.
+	NaNPropagate NaNPolicy = iota
+	// NaNSkip ignores NaN values. Results remain aligned with the input,
+	// and moving statistics are calculated over the valid values inside
+	// each window.
+	NaNSkip
+	// NaNInterpolate replaces NaN values by linear interpolation between
+	// the nearest valid values, or by the nearest valid value at the ends
+	// of the series.
+	NaNInterpolate
+	// NaNError rejects input with NaN values with ErrNaNInput. Functions
+	// that do not return an error treat it like NaNPropagate.
+	NaNError
+)
+
+// MedianNaN is like Median, but handles NaN values in x according to
+// policy.
+func MedianNaN(x []float64, policy NaNPolicy) (float64, error) {
+	v, err := applyNaNPolicy(x, policy)
+	if err != nil {
+		return math.NaN(), err
+	}
+	if hasNaN(v) {
+		return math.NaN(), nil
+	}
+	return Median(v), nil
+}
+
+// MADNaN is like MAD, but handles NaN values in x according to policy.
+func MADNaN(x []float64, policy NaNPolicy) (float64, error) {
+	v, err := applyNaNPolicy(x, policy)
+	if err != nil {
+		return math.NaN(), err
+	}
+	if hasNaN(v) {
+		return math.NaN(), nil
+	}
+	return MAD(v), nil
+}
+
+// NormalizeNaN is like Normalize, but handles NaN values in x according to
+// policy. With NaNSkip the z-scores are calculated from the mean and the
+// standard deviation of the valid values, and remain NaN where x is NaN.
+func NormalizeNaN(x, weights []float64, policy NaNPolicy) ([]float64, error) {
+	if err := checkWeights(weights, len(x)); err != nil {
+		return nil, err
+	}
+	if policy != NaNSkip {
+		v, err := applyNaNPolicy(x, policy)
+		if err != nil {
+			return nil, err
+		}
+		return Normalize(v, weights), nil
+	}
+
+	if err := checkInput(x, 1); err != nil {
+		return nil, err
+	}
+	var v, w []float64
+	for i := 0; i < len(x); i++ {
+		if !math.IsNaN(x[i]) {
+			v = append(v, x[i])
+			if weights != nil {
+				w = append(w, weights[i])
+			}
+		}
+	}
+	zscores := make([]float64, len(x))
+	mean := Mean(v, w)
+	stdDev := StdDev(v, w, VarianceTwoPass)
+	for i := 0; i < len(x); i++ {
+		switch {
+		case math.IsNaN(x[i]):
+			zscores[i] = math.NaN()
+		case stdDev != 0.0:
+			zscores[i] = (x[i] - mean) / stdDev
+		default:
+			zscores[i] = x[i] - mean
+		}
+	}
+	return zscores, nil
+}
+
+// VolatilityNaN is like Volatility, but handles NaN prices in x according
+// to policy. With NaNSkip the returns from or to a NaN price are ignored.
+func VolatilityNaN(x []float64, periodicity float64, policy NaNPolicy) (float64, error) {
+	if policy != NaNSkip {
+		v, err := applyNaNPolicy(x, policy)
+		if err != nil {
+			return math.NaN(), err
+		}
+		return Volatility(v, periodicity), nil
+	}
+
+	if err := checkInput(x, 2); err != nil {
+		return math.NaN(), err
+	}
+	var rets []float64
+	for i := 1; i < len(x); i++ {
+		if r := math.Log(x[i] / x[i-1]); !math.IsNaN(r) {
+			rets = append(rets, r)
+		}
+	}
+	return StdDev(rets, nil, VarianceTwoPass) * math.Sqrt(periodicity), nil
+}
+
+// applyNaNPolicy returns x with NaN values handled according to policy,
+// which for NaNSkip means removing them.
+func applyNaNPolicy(x []float64, policy NaNPolicy) ([]float64, error) {
+	if len(x) == 0 {
+		return nil, ErrEmptyInput
+	}
+	switch policy {
+	case NaNSkip:
+		v := make([]float64, 0, len(x))
+		for i := 0; i < len(x); i++ {
+			if !math.IsNaN(x[i]) {
+				v = append(v, x[i])
+			}
+		}
+		if len(v) == 0 {
+			return nil, ErrNaNInput
+		}
+		return v, nil
+	case NaNInterpolate:
+		v := interpolateNaNs(x)
+		if v == nil {
+			return nil, ErrNaNInput
+		}
+		return v, nil
+	case NaNError:
+		if hasNaN(x) {
+			return nil, ErrNaNInput
+		}
+	}
+	return x, nil
+}
+
+// interpolateNaNs returns a copy of x with NaN values replaced by linear
+// interpolation between the nearest valid values, or x itself if it has no
+// NaN values. It returns nil if all values are NaN.
+func interpolateNaNs(x []float64) []float64 {
+	if !hasNaN(x) {
+		return x
+	}
+	v := append([]float64{}, x...)
+	prev := -1
+	for i := 0; i <= len(v); i++ {
+		if i < len(v) && math.IsNaN(v[i]) {
+			continue
+		}
+		switch {
+		case i == len(v) && prev < 0:
+			return nil
+		case i == len(v):
+			// trailing NaNs take the last valid value
+			for j := prev + 1; j < i; j++ {
+				v[j] = v[prev]
+			}
+		case prev < 0:
+			// leading NaNs take the first valid value
+			for j := 0; j < i; j++ {
+				v[j] = v[i]
+			}
+		default:
+			step := (v[i] - v[prev]) / float64(i-prev)
+			for j := prev + 1; j < i; j++ {
+				v[j] = v[prev] + float64(j-prev)*step
+			}
+		}
+		prev = i
+	}
+	return v
+}
+
+func hasNaN(x []float64) bool {
+	for i := 0; i < len(x); i++ {
+		if math.IsNaN(x[i]) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,123 @@
+package gostat
+
+import (
+	"math"
+	"testing"
+)
+
+func TestMedianNaN(t *testing.T) {
+	x := []float64{1., math.NaN(), 3., 4.}
+	cases := []struct {
+		policy NaNPolicy
+		want   float64
+		err    error
+	}{
+		{NaNPropagate, math.NaN(), nil},
+		{NaNSkip, 3., nil},
+		{NaNInterpolate, 2.5, nil},
+		{NaNError, math.NaN(), ErrNaNInput},
+	}
+	for _, c := range cases {
+		median, err := MedianNaN(x, c.policy)
+		if err != c.err {
+			t.Errorf("Expected error=%v, got=%v", c.err, err)
+		}
+		if got := median; !floatEquals(got, c.want) {
+			t.Errorf("Expected median=%f, got=%f", c.want, got)
+		}
+	}
+}
+
+func TestMedianNaN_AllNaN(t *testing.T) {
+	x := []float64{math.NaN(), math.NaN()}
+	for _, policy := range []NaNPolicy{NaNSkip, NaNInterpolate, NaNError} {
+		if _, err := MedianNaN(x, policy); err != ErrNaNInput {
+			t.Errorf("Expected error=%v, got=%v", ErrNaNInput, err)
+		}
+	}
+	if _, err := MedianNaN(nil, NaNSkip); err != ErrEmptyInput {
+		t.Errorf("Expected error=%v, got=%v", ErrEmptyInput, err)
+	}
+}
+
+func TestMADNaN(t *testing.T) {
+	x := []float64{2., 6., math.NaN(), 6., 12., 17., 25., 32.}
+	mad, err := MADNaN(x, NaNSkip)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if got, want := mad, 8.8956; !floatEquals(got, want) {
+		t.Errorf("Expected MAD=%f, got=%f", want, got)
+	}
+}
+
+func TestNormalizeNaN_Skip(t *testing.T) {
+	scores := []float64{35., math.NaN(), 36., 46., 68., 70.}
+	zscores, err := NormalizeNaN(scores, nil, NaNSkip)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	compareArrays([]float64{-0.9412, math.NaN(), -0.8824, -0.2941, 1.0000, 1.1176}, zscores, t)
+}
+
+func TestNormalizeNaN_Propagate(t *testing.T) {
+	zscores, err := NormalizeNaN([]float64{1., math.NaN(), 3.}, nil, NaNPropagate)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	compareArrays([]float64{math.NaN(), math.NaN(), math.NaN()}, zscores, t)
+}
+
+func TestVolatilityNaN(t *testing.T) {
+	prices := []float64{100., 101., math.NaN(), 102., 101., 103.}
+	vol, err := VolatilityNaN(prices, 252., NaNSkip)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	rets := []float64{math.Log(101. / 100.), math.Log(101. / 102.), math.Log(103. / 101.)}
+	want := Volatility([]float64{1., math.Exp(rets[0]), math.Exp(rets[0] + rets[1]), math.Exp(rets[0] + rets[1] + rets[2])}, 252.)
+	if got := vol; !floatEquals(got, want) {
+		t.Errorf("Expected volatility=%f, got=%f", want, got)
+	}
+	if _, err := VolatilityNaN(prices, 252., NaNError); err != ErrNaNInput {
+		t.Errorf("Expected error=%v, got=%v", ErrNaNInput, err)
+	}
+}
+
+func TestInterpolateNaNs(t *testing.T) {
+	x := []float64{math.NaN(), 2., math.NaN(), math.NaN(), 5., math.NaN()}
+	compareArrays([]float64{2., 2., 3., 4., 5., 5.}, interpolateNaNs(x), t)
+	if v := interpolateNaNs([]float64{math.NaN()}); v != nil {
+		t.Errorf("Expected nil, got=%v", v)
+	}
+}
+
+func TestMovMean_NaNSkip(t *testing.T) {
+	x := []float64{1., math.NaN(), 3., 4., 5.}
+	m := MovMean(x, 3, WindowOpts{NaNPolicy: NaNSkip})
+	compareArrays([]float64{1., 2., 3.5, 4., 4.5}, m, t)
+	m = MovMean(x, 3, WindowOpts{NaNPolicy: NaNSkip, Weights: []float64{1., 1., 1.}})
+	compareArrays([]float64{1., 2., 3.5, 4., 4.5}, m, t)
+}
+
+func TestMovMedian_NaNSkip(t *testing.T) {
+	x := []float64{1., math.NaN(), math.NaN(), 4., 5.}
+	m := MovMedian(x, 2, WindowOpts{NaNPolicy: NaNSkip, Trailing: true})
+	compareArrays([]float64{1., 1., math.NaN(), 4., 4.5}, m, t)
+	m = MovMax(x, 2, WindowOpts{NaNPolicy: NaNSkip, Trailing: true})
+	compareArrays([]float64{1., 1., math.NaN(), 4., 5.}, m, t)
+}
+
+func TestMovMean_NaNInterpolate(t *testing.T) {
+	x := []float64{4., 8., math.NaN(), -1., -2.}
+	m := MovMean(x, 3, WindowOpts{NaNPolicy: NaNInterpolate})
+	compareArrays([]float64{6., 5.1667, 3.5, 0.1667, -1.5}, m, t)
+}
+
+func TestMovApplyE_NaNError(t *testing.T) {
+	x := []float64{1., math.NaN(), 3.}
+	fn := func(window, _ []float64) float64 { return 0. }
+	if _, err := MovApplyE(x, 2, WindowOpts{NaNPolicy: NaNError}, fn); err != ErrNaNInput {
+		t.Errorf("Expected error=%v, got=%v", ErrNaNInput, err)
+	}
+}
@@ -0,0 +1,182 @@
+package gostat
+
+import (
+	"math"
+	"sort"
+)
+
+// Scaler selects how a set of scores is normalized.
+type Scaler int
+
+const (
+	// ScalerZScore subtracts the mean and divides by the standard
+	// deviation, see Normalize.
+	ScalerZScore Scaler = iota
+	// ScalerRobust subtracts the median and divides by the MAD, see
+	// NormalizeRobust.
+	ScalerRobust
+	// ScalerMinMax maps the scores linearly onto a range, see
+	// NormalizeMinMax.
+	ScalerMinMax
+	// ScalerRank replaces the scores by their percentile ranks, see
+	// NormalizeRank.
+	ScalerRank
+)
+
+// NormalizeOpts controls how NormalizeWith normalizes a set of scores.
+type NormalizeOpts struct {
+	// Scaler selects the normalization method.
+	Scaler Scaler
+	// Weights of the scores for ScalerZScore, nil for equal weights.
+	Weights []float64
+	// Min and Max are the bounds of the range for ScalerMinMax. When both
+	// are zero the scores are mapped onto [0, 1].
+	Min, Max float64
+}
+
+// NormalizeWith normalizes a set of scores x with the method selected by
+// opts.
+func NormalizeWith(x []float64, opts NormalizeOpts) []float64 {
+	switch opts.Scaler {
+	case ScalerRobust:
+		return NormalizeRobust(x)
+	case ScalerMinMax:
+		if opts.Min == 0 && opts.Max == 0 {
+			return NormalizeMinMax(x, 0, 1)
+		}
+		return NormalizeMinMax(x, opts.Min, opts.Max)
+	case ScalerRank:
+		return NormalizeRank(x)
+	}
+	return Normalize(x, opts.Weights)
+}
+
+// NormalizeRobust is normalizing a set of scores x using the median and the
+// MAD in place of the mean and the standard deviation used by Normalize.
+// Unlike z-scores, robust scores of heavy-tailed data are not dominated by
+// a few outlying values. When MAD is zero, as when more than half of the
+// scores are equal, the scores are normalized by Normalize instead, see
+// NormalizeRobustScaler.
+func NormalizeRobust(x []float64) []float64 {
+	scores, _ := NormalizeRobustScaler(x)
+	return scores
+}
+
+// NormalizeRobustScaler is like NormalizeRobust, but also returns the scaler
+// used, ScalerRobust, or ScalerZScore when MAD is zero, so that the scores
+// never silently degenerate into deviations from the median in the units
+// of x.
+func NormalizeRobustScaler(x []float64) ([]float64, Scaler) {
+	scores := make([]float64, len(x))
+	if len(x) == 0 {
+		return scores, ScalerRobust
+	}
+	median := Median(x)
+	mad := MAD(x)
+	if mad == 0.0 {
+		return NormalizeTo(scores, x, nil), ScalerZScore
+	}
+	for i := 0; i < len(x); i++ {
+		scores[i] = (x[i] - median) / mad
+	}
+	return scores, ScalerRobust
+}
+
+// MovNormalizeRobust normalizes each value of x by the median and the MAD of
+// the sliding window of length k anchored at it, selected by opts, such as
+// the trailing window of past values for a signal free of look-ahead, and
+// returns the scaler used for each value, ScalerRobust, or ScalerZScore
+// where the MAD of the window is zero and its mean and standard deviation
+// are used instead, as by NormalizeRobustScaler. With weights in opts each
+// window uses the WeightedMedian and the WeightedMAD. The output is padded
+// as if Pad was set in opts, so that both slices are aligned with x, with
+// NaN scores and ScalerRobust where there is no window.
+func MovNormalizeRobust(x []float64, k int, opts WindowOpts) ([]float64, []Scaler) {
+	opts.Pad = true
+	stats := MovApplyMulti(x, k, opts, 4, func(window, weights, dst []float64) {
+		dst[0] = WeightedMedian(window, weights)
+		dst[1] = WeightedMAD(window, weights)
+		dst[2] = Mean(window, weights)
+		dst[3] = StdDev(window, weights, VarianceTwoPass)
+	})
+	scores := make([]float64, len(x))
+	scalers := make([]Scaler, len(x))
+	for i := 0; i < len(x); i++ {
+		median, mad, mean, stdDev := stats[0][i], stats[1][i], stats[2][i], stats[3][i]
+		switch {
+		case mad != 0.0:
+			scores[i] = (x[i] - median) / mad
+			scalers[i] = ScalerRobust
+		case stdDev != 0.0 && !math.IsNaN(stdDev):
+			scores[i] = (x[i] - mean) / stdDev
+			scalers[i] = ScalerZScore
+		default:
+			scores[i] = x[i] - mean
+			scalers[i] = ScalerZScore
+		}
+	}
+	return scores, scalers
+}
+
+// NormalizeMinMax is normalizing a set of scores x by mapping them linearly
+// onto the range [min, max], the smallest score to min and the largest one
+// to max. When all scores are equal they are mapped to min.
+func NormalizeMinMax(x []float64, min, max float64) []float64 {
+	scores := make([]float64, len(x))
+	if len(x) == 0 {
+		return scores
+	}
+	lo, hi := x[0], x[0]
+	for i := 1; i < len(x); i++ {
+		lo = math.Min(lo, x[i])
+		hi = math.Max(hi, x[i])
+	}
+	for i := 0; i < len(x); i++ {
+		if hi != lo {
+			scores[i] = min + (x[i]-lo)/(hi-lo)*(max-min)
+		} else {
+			scores[i] = min
+		}
+	}
+	return scores
+}
+
+// NormalizeRank is normalizing a set of scores x by replacing them with
+// their percentile ranks in [0, 1], the smallest score being 0 and the
+// largest 1. Equal scores get the average of their ranks. The rank
+// transform only keeps the order of the scores, so it is insensitive to
+// outliers and to any monotone distortion of the data.
+func NormalizeRank(x []float64) []float64 {
+	scores := ranks(x)
+	for i := 0; i < len(scores); i++ {
+		if len(x) > 1 {
+			scores[i] = (scores[i] - 1) / float64(len(x)-1)
+		} else {
+			scores[i] = 0.5
+		}
+	}
+	return scores
+}
+
+// ranks returns the ranks of the elements of x starting from 1, where equal
+// elements get the average of their ranks.
+func ranks(x []float64) []float64 {
+	idx := make([]int, len(x))
+	for i := 0; i < len(idx); i++ {
+		idx[i] = i
+	}
+	sort.Stable(byValue{idx, x})
+
+	r := make([]float64, len(x))
+	for i := 0; i < len(idx); {
+		j := i + 1
+		for j < len(idx) && x[idx[j]] == x[idx[i]] {
+			j++
+		}
+		rank := 0.5 * float64(i+j+1)
+		for ; i < j; i++ {
+			r[idx[i]] = rank
+		}
+	}
+	return r
+}
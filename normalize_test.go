@@ -0,0 +1,58 @@
+package gostat
+
+import (
+	"math"
+	"testing"
+)
+
+func TestNormalizeRobust(t *testing.T) {
+	x := []float64{2., 6., 6., 12., 17., 25., 32.}
+	compareArrays([]float64{-1.1242, -0.6745, -0.6745, 0., 0.5621, 1.4614, 2.2483}, NormalizeRobust(x), t)
+}
+
+func TestNormalizeRobust_ZeroMAD(t *testing.T) {
+	x := []float64{1., 1., 1., 5.}
+	compareArrays([]float64{-0.5, -0.5, -0.5, 1.5}, NormalizeRobust(x), t)
+	scores, scaler := NormalizeRobustScaler(x)
+	compareArrays(Normalize(x, nil), scores, t)
+	if scaler != ScalerZScore {
+		t.Errorf("Expected scaler=%d, got=%d", ScalerZScore, scaler)
+	}
+	if _, scaler := NormalizeRobustScaler([]float64{1., 2., 4.}); scaler != ScalerRobust {
+		t.Errorf("Expected scaler=%d, got=%d", ScalerRobust, scaler)
+	}
+}
+
+func TestMovNormalizeRobust(t *testing.T) {
+	x := []float64{1., 2., 4., 4., 4., 4., 5., math.NaN()}
+	scores, scalers := MovNormalizeRobust(x, 3, WindowOpts{Trailing: true, FullWindow: true})
+	compareArrays([]float64{math.NaN(), math.NaN(), 1.3490, 0.5774, 0., 0., 1.1547, math.NaN()}, scores, t)
+	want := []Scaler{ScalerRobust, ScalerRobust, ScalerRobust, ScalerZScore, ScalerZScore, ScalerZScore, ScalerZScore, ScalerZScore}
+	for i := 0; i < len(want); i++ {
+		if scalers[i] != want[i] {
+			t.Errorf("Expected scaler at %d=%d, got=%d", i, want[i], scalers[i])
+		}
+	}
+}
+
+func TestNormalizeMinMax(t *testing.T) {
+	x := []float64{35., 36., 46., 68., 70.}
+	compareArrays([]float64{0., 0.0286, 0.3143, 0.9429, 1.}, NormalizeMinMax(x, 0., 1.), t)
+	compareArrays([]float64{-1., -0.9429, -0.3714, 0.8857, 1.}, NormalizeMinMax(x, -1., 1.), t)
+	compareArrays([]float64{5., 5.}, NormalizeMinMax([]float64{3., 3.}, 5., 10.), t)
+}
+
+func TestNormalizeRank(t *testing.T) {
+	x := []float64{10., 30., 20., 20., 100.}
+	compareArrays([]float64{0., 0.75, 0.375, 0.375, 1.}, NormalizeRank(x), t)
+	compareArrays([]float64{0.5}, NormalizeRank([]float64{7.}), t)
+}
+
+func TestNormalizeWith(t *testing.T) {
+	x := []float64{35., 36., 46., 68., 70.}
+	compareArrays(Normalize(x, nil), NormalizeWith(x, NormalizeOpts{}), t)
+	compareArrays(NormalizeRobust(x), NormalizeWith(x, NormalizeOpts{Scaler: ScalerRobust}), t)
+	compareArrays(NormalizeMinMax(x, 0., 1.), NormalizeWith(x, NormalizeOpts{Scaler: ScalerMinMax}), t)
+	compareArrays(NormalizeMinMax(x, 1., 5.), NormalizeWith(x, NormalizeOpts{Scaler: ScalerMinMax, Min: 1., Max: 5.}), t)
+	compareArrays(NormalizeRank(x), NormalizeWith(x, NormalizeOpts{Scaler: ScalerRank}), t)
+}
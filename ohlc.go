@@ -0,0 +1,124 @@
+package gostat
+
+import (
+	"math"
+)
+
+// VolatilityEstimator selects how volatility is estimated from a window of
+// open, high, low and close prices.
+type VolatilityEstimator int
+
+const (
+	// EstimatorCloseToClose uses the close prices only, see Volatility.
+	EstimatorCloseToClose VolatilityEstimator = iota
+	// EstimatorParkinson uses the high and low prices, see
+	// VolatilityParkinson.
+	EstimatorParkinson
+	// EstimatorGarmanKlass uses all prices, see VolatilityGarmanKlass.
+	EstimatorGarmanKlass
+	// EstimatorRogersSatchell uses all prices, see VolatilityRogersSatchell.
+	EstimatorRogersSatchell
+	// EstimatorYangZhang uses all prices, see VolatilityYangZhang.
+	EstimatorYangZhang
+)
+
+// VolatilityParkinson calculates historical volatility from high and low
+// prices with the Parkinson estimator, which is about five times more
+// efficient than the close-to-close estimator but assumes no drift and no
+// opening jumps.
+func VolatilityParkinson(high, low []float64, periodicity float64) float64 {
+	checkSeries([][]float64{high, low})
+	var sum neumaierSum
+	for i := 0; i < len(high); i++ {
+		hl := math.Log(high[i] / low[i])
+		sum.add(hl * hl)
+	}
+	return math.Sqrt(sum.total() / (4 * math.Ln2 * float64(len(high))) * periodicity)
+}
+
+// VolatilityGarmanKlass calculates historical volatility from open, high,
+// low and close prices with the Garman-Klass estimator, which assumes no
+// drift and no opening jumps.
+func VolatilityGarmanKlass(open, high, low, close []float64, periodicity float64) float64 {
+	checkSeries([][]float64{open, high, low, close})
+	var sum neumaierSum
+	for i := 0; i < len(open); i++ {
+		hl := math.Log(high[i] / low[i])
+		co := math.Log(close[i] / open[i])
+		sum.add(0.5*hl*hl - (2*math.Ln2-1)*co*co)
+	}
+	return math.Sqrt(sum.total() / float64(len(open)) * periodicity)
+}
+
+// VolatilityRogersSatchell calculates historical volatility from open, high,
+// low and close prices with the Rogers-Satchell estimator, which allows for
+// drift but assumes no opening jumps.
+func VolatilityRogersSatchell(open, high, low, close []float64, periodicity float64) float64 {
+	checkSeries([][]float64{open, high, low, close})
+	return math.Sqrt(rogersSatchell(open, high, low, close) * periodicity)
+}
+
+// VolatilityYangZhang calculates historical volatility from open, high, low
+// and close prices with the Yang-Zhang estimator, which allows for drift and
+// opening jumps. It combines the variance of the overnight returns from the
+// previous close to the open, the variance of the open to close returns and
+// the Rogers-Satchell variance, so the first bar only provides its close.
+func VolatilityYangZhang(open, high, low, close []float64, periodicity float64) float64 {
+	n := checkSeries([][]float64{open, high, low, close}) - 1
+	if n < 2 {
+		return math.NaN()
+	}
+	overnight := make([]float64, n)
+	intraday := make([]float64, n)
+	for i := 1; i <= n; i++ {
+		overnight[i-1] = math.Log(open[i] / close[i-1])
+		intraday[i-1] = math.Log(close[i] / open[i])
+	}
+	k := 0.34 / (1.34 + float64(n+1)/float64(n-1))
+	rs := rogersSatchell(open[1:], high[1:], low[1:], close[1:])
+	variance := Variance(overnight, nil, VarianceTwoPass) + k*Variance(intraday, nil, VarianceTwoPass) + (1-k)*rs
+	return math.Sqrt(variance * periodicity)
+}
+
+// MovVolatilityOHLC returns moving volatility, a slice of historical
+// volatilities calculated with estimator over sliding windows of length k
+// across the bars given by open, high, low and close prices. The windows are
+// selected the same way as by RollingWindow. Weights and NaN handling
+// options are ignored.
+func MovVolatilityOHLC(open, high, low, close []float64, k int, periodicity float64, estimator VolatilityEstimator, opts WindowOpts) []float64 {
+	n := checkSeries([][]float64{open, high, low, close})
+	it := newWindowIterOpts(n, n, k, opts, nil)
+	rets := make([]float64, 0, it.len())
+	for it.next() {
+		if it.pad {
+			rets = append(rets, math.NaN())
+			continue
+		}
+		o, h := open[it.start:it.end], high[it.start:it.end]
+		l, c := low[it.start:it.end], close[it.start:it.end]
+		var vol float64
+		switch estimator {
+		case EstimatorParkinson:
+			vol = VolatilityParkinson(h, l, periodicity)
+		case EstimatorGarmanKlass:
+			vol = VolatilityGarmanKlass(o, h, l, c, periodicity)
+		case EstimatorRogersSatchell:
+			vol = VolatilityRogersSatchell(o, h, l, c, periodicity)
+		case EstimatorYangZhang:
+			vol = VolatilityYangZhang(o, h, l, c, periodicity)
+		default:
+			vol = Volatility(c, periodicity)
+		}
+		rets = append(rets, vol)
+	}
+	return rets
+}
+
+func rogersSatchell(open, high, low, close []float64) float64 {
+	var sum float64
+	for i := 0; i < len(open); i++ {
+		sum += math.Log(high[i]/close[i])*math.Log(high[i]/open[i]) +
+			math.Log(low[i]/close[i])*math.Log(low[i]/open[i])
+	}
+	return sum / float64(len(open))
+}
@@ -0,0 +1,61 @@
+package gostat
+
+import (
+	"math"
+	"testing"
+)
+
+var (
+	testOpen  = []float64{100., 102., 101., 103., 104.}
+	testHigh  = []float64{103., 104., 103., 106., 105.}
+	testLow   = []float64{99., 100., 99., 102., 101.}
+	testClose = []float64{102., 101., 102., 105., 103.}
+)
+
+func TestVolatilityParkinson(t *testing.T) {
+	if got, want := VolatilityParkinson(testHigh, testLow, 252.), 0.3733; !floatEquals(got, want) {
+		t.Errorf("Expected volatility=%f, got=%f", want, got)
+	}
+}
+
+func TestVolatilityGarmanKlass(t *testing.T) {
+	if got, want := VolatilityGarmanKlass(testOpen, testHigh, testLow, testClose, 252.), 0.4156; !floatEquals(got, want) {
+		t.Errorf("Expected volatility=%f, got=%f", want, got)
+	}
+}
+
+func TestVolatilityRogersSatchell(t *testing.T) {
+	if got, want := VolatilityRogersSatchell(testOpen, testHigh, testLow, testClose, 252.), 0.4173; !floatEquals(got, want) {
+		t.Errorf("Expected volatility=%f, got=%f", want, got)
+	}
+}
+
+func TestVolatilityYangZhang(t *testing.T) {
+	if got, want := VolatilityYangZhang(testOpen, testHigh, testLow, testClose, 252.), 0.4265; !floatEquals(got, want) {
+		t.Errorf("Expected volatility=%f, got=%f", want, got)
+	}
+	if got := VolatilityYangZhang(testOpen[:2], testHigh[:2], testLow[:2], testClose[:2], 252.); !math.IsNaN(got) {
+		t.Errorf("Expected volatility=NaN, got=%f", got)
+	}
+}
+
+func TestMovVolatilityOHLC(t *testing.T) {
+	vols := MovVolatilityOHLC(testOpen, testHigh, testLow, testClose, 5, 252., EstimatorParkinson, WindowOpts{FullWindow: true})
+	compareArrays([]float64{0.3733}, vols, t)
+
+	vols = MovVolatilityOHLC(testOpen, testHigh, testLow, testClose, 2, 252., EstimatorGarmanKlass, WindowOpts{Trailing: true})
+	if got, want := len(vols), len(testOpen); got != want {
+		t.Fatalf("Expected number of elements=%d, got=%d", want, got)
+	}
+	want := VolatilityGarmanKlass(testOpen[3:], testHigh[3:], testLow[3:], testClose[3:], 252.)
+	if got := vols[4]; !floatEquals(got, want) {
+		t.Errorf("Expected volatility=%f, got=%f", want, got)
+	}
+
+	vols = MovVolatilityOHLC(testOpen, testHigh, testLow, testClose, 3, 252., EstimatorCloseToClose, WindowOpts{FullWindow: true})
+	compareArrays([]float64{
+		Volatility(testClose[:3], 252.),
+		Volatility(testClose[1:4], 252.),
+		Volatility(testClose[2:], 252.),
+	}, vols, t)
+}
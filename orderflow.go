@@ -0,0 +1,75 @@
+package gostat
+
+import (
+	"math"
+)
+
+// TickRule classifies trades at prices as buyer initiated, +1, when the
+// price is above the last different price, and seller initiated, -1, when it
+// is below. Trades before the first price change are not classified and
+// get 0.
+func TickRule(prices []float64) []int {
+	signs := make([]int, len(prices))
+	var last int
+	for i := 1; i < len(prices); i++ {
+		switch {
+		case prices[i] > prices[i-1]:
+			last = 1
+		case prices[i] < prices[i-1]:
+			last = -1
+		}
+		signs[i] = last
+	}
+	return signs
+}
+
+// OrderFlowImbalance returns the order flow imbalance of trades with given
+// volumes and signs from TickRule, the difference between the buy and the
+// sell volume divided by the classified volume, from -1 when all volume is
+// sold to 1 when all volume is bought. NaN is returned when no volume is
+// classified.
+func OrderFlowImbalance(volume []float64, signs []int) float64 {
+	if len(volume) != len(signs) {
+		panic("gostat: slice length mismatch")
+	}
+	var net, total float64
+	for i := 0; i < len(volume); i++ {
+		if signs[i] == 0 {
+			continue
+		}
+		net += float64(signs[i]) * volume[i]
+		total += volume[i]
+	}
+	if total == 0 {
+		return math.NaN()
+	}
+	return net / total
+}
+
+// MovOrderFlowImbalance returns moving order flow imbalance, a slice of the
+// local k-point OrderFlowImbalance values over windows selected the same
+// way as by MovApply. Unclassified trades count towards the windows without
+// adding any volume, and the NaN policy in opts applies to NaN volumes.
+// Weights are ignored.
+func MovOrderFlowImbalance(volume []float64, signs []int, k int, opts WindowOpts) []float64 {
+	if len(volume) != len(signs) {
+		panic("gostat: slice length mismatch")
+	}
+	signed := make([]float64, len(volume))
+	for i := 0; i < len(volume); i++ {
+		if signs[i] != 0 {
+			signed[i] = float64(signs[i]) * volume[i]
+		}
+	}
+	return MovApply(signed, k, opts, func(window, _ []float64) float64 {
+		var net, total float64
+		for i := 0; i < len(window); i++ {
+			net += window[i]
+			total += math.Abs(window[i])
+		}
+		if total == 0 {
+			return math.NaN()
+		}
+		return net / total
+	})
+}
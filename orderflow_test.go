@@ -0,0 +1,38 @@
+package gostat
+
+import (
+	"math"
+	"testing"
+)
+
+func TestTickRule(t *testing.T) {
+	prices := []float64{10., 10., 10.1, 10.1, 10.05, 10.05, 10.2}
+	compareIndices([]int{0, 0, 1, 1, -1, -1, 1}, TickRule(prices), t)
+}
+
+func TestOrderFlowImbalance(t *testing.T) {
+	volume := []float64{100., 50., 200., 100., 300., 100., 50.}
+	signs := []int{0, 0, 1, 1, -1, -1, 1}
+	if got, want := OrderFlowImbalance(volume, signs), -50./750.; !floatEquals(got, want) {
+		t.Errorf("Expected imbalance=%f, got=%f", want, got)
+	}
+	if got := OrderFlowImbalance(volume[:2], signs[:2]); !math.IsNaN(got) {
+		t.Errorf("Expected imbalance=NaN, got=%f", got)
+	}
+}
+
+func TestMovOrderFlowImbalance(t *testing.T) {
+	volume := []float64{100., 50., 200., 100., 300., 100., 50.}
+	signs := []int{0, 0, 1, 1, -1, -1, 1}
+	m := MovOrderFlowImbalance(volume, signs, 3, WindowOpts{Trailing: true})
+	compareArrays([]float64{math.NaN(), math.NaN(), 1., 1., 0., -0.6, -7. / 9.}, m, t)
+}
+
+func TestMovOrderFlowImbalance_NaNPolicy(t *testing.T) {
+	volume := []float64{100., math.NaN(), 200., 100.}
+	signs := []int{1, -1, -1, 0}
+	m := MovOrderFlowImbalance(volume, signs, 2, WindowOpts{Trailing: true})
+	compareArrays([]float64{1., math.NaN(), math.NaN(), -1.}, m, t)
+	m = MovOrderFlowImbalance(volume, signs, 2, WindowOpts{Trailing: true, NaNPolicy: NaNSkip})
+	compareArrays([]float64{1., 1., -1., -1.}, m, t)
+}
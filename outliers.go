@@ -0,0 +1,148 @@
+package gostat
+
+import (
+	"github.com/gonum/stat/distuv"
+	"math"
+	"sort"
+)
+
+// OutliersMAD returns the indices of the elements of x whose modified
+// z-score, the absolute deviation from the median in units of MAD, exceeds
+// threshold. A commonly used threshold is 3.5.
+//
+// When more than half of the values are equal MAD is zero, and the mean
+// absolute deviation from the median scaled by 1.2533 is used instead.
+func OutliersMAD(x []float64, threshold float64) []int {
+	if len(x) == 0 {
+		return nil
+	}
+	median := Median(x)
+	scale := MAD(x)
+	if scale == 0 {
+		var sum neumaierSum
+		for i := 0; i < len(x); i++ {
+			sum.add(math.Abs(x[i] - median))
+		}
+		scale = 1.2533 * sum.total() / float64(len(x))
+	}
+	return outliers(x, func(v float64) bool {
+		return math.Abs(v-median) > threshold*scale
+	})
+}
+
+// OutliersZScore returns the indices of the elements of x whose absolute
+// z-score, as calculated by Normalize, exceeds threshold.
+func OutliersZScore(x []float64, threshold float64) []int {
+	zscores := Normalize(x, nil)
+	return outliers(zscores, func(z float64) bool {
+		return math.Abs(z) > threshold
+	})
+}
+
+// OutliersIQR returns the indices of the elements of x outside of the Tukey
+// fences, the first quartile minus k interquartile ranges and the third
+// quartile plus k interquartile ranges. k is typically 1.5 for outliers
+// and 3 for far outliers.
+func OutliersIQR(x []float64, k float64) []int {
+	if len(x) == 0 {
+		return nil
+	}
+	qs := Quantiles(x, []float64{0.25, 0.75}, QuantileLinear)
+	q1, q3 := qs[0], qs[1]
+	lower, upper := q1-k*(q3-q1), q3+k*(q3-q1)
+	return outliers(x, func(v float64) bool {
+		return v < lower || v > upper
+	})
+}
+
+// OutliersGrubbs returns the indices of the elements of x found to be
+// outliers by the iterated two-sided Grubbs test at significance level
+// alpha, typically 0.05. The test assumes the remaining elements are
+// normally distributed, and repeatedly removes the element farthest from
+// their mean while its distance in standard deviations exceeds the critical
+// value, so that it finds several outliers one at a time. The indices are
+// in ascending order.
+func OutliersGrubbs(x []float64, alpha float64) []int {
+	rest := make([]int, len(x))
+	for i := 0; i < len(x); i++ {
+		rest[i] = i
+	}
+	var idx []int
+	v := make([]float64, 0, len(x))
+	for len(rest) >= 3 {
+		v = v[:0]
+		for _, i := range rest {
+			v = append(v, x[i])
+		}
+		mean := Mean(v, nil)
+		sd := StdDev(v, nil, VarianceTwoPass)
+		if !(sd > 0) {
+			break
+		}
+		far, g := 0, 0.
+		for j := 0; j < len(v); j++ {
+			if d := math.Abs(v[j] - mean); d > g {
+				far, g = j, d
+			}
+		}
+		n := float64(len(v))
+		tq := distuv.StudentsT{Sigma: 1, Nu: n - 2}.Quantile(1 - alpha/(2*n))
+		if g/sd <= (n-1)/math.Sqrt(n)*math.Sqrt(tq*tq/(n-2+tq*tq)) {
+			break
+		}
+		idx = append(idx, rest[far])
+		rest = append(rest[:far], rest[far+1:]...)
+	}
+	sort.Ints(idx)
+	return idx
+}
+
+// RemoveOutliers returns a copy of x without the elements at the indices
+// listed in idx.
+func RemoveOutliers(x []float64, idx []int) []float64 {
+	skip := make(map[int]bool, len(idx))
+	for _, i := range idx {
+		skip[i] = true
+	}
+	v := make([]float64, 0, len(x))
+	for i := 0; i < len(x); i++ {
+		if !skip[i] {
+			v = append(v, x[i])
+		}
+	}
+	return v
+}
+
+// WinsorizeOutliers returns a copy of x where the elements at the indices
+// listed in idx are replaced by the nearest of the smallest and the largest
+// of the remaining elements.
+func WinsorizeOutliers(x []float64, idx []int) []float64 {
+	inliers := RemoveOutliers(x, idx)
+	v := append([]float64{}, x...)
+	if len(inliers) == 0 {
+		return v
+	}
+	lo, hi := inliers[0], inliers[0]
+	for i := 1; i < len(inliers); i++ {
+		lo = math.Min(lo, inliers[i])
+		hi = math.Max(hi, inliers[i])
+	}
+	for _, i := range idx {
+		if v[i] < lo {
+			v[i] = lo
+		} else if v[i] > hi {
+			v[i] = hi
+		}
+	}
+	return v
+}
+
+func outliers(x []float64, isOutlier func(float64) bool) []int {
+	var idx []int
+	for i := 0; i < len(x); i++ {
+		if isOutlier(x[i]) {
+			idx = append(idx, i)
+		}
+	}
+	return idx
+}
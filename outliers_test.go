@@ -0,0 +1,62 @@
+package gostat
+
+import (
+	"testing"
+)
+
+func TestOutliersMAD(t *testing.T) {
+	x := []float64{2., 6., 6., 12., 17., 25., 32., 120.}
+	compareIndices([]int{7}, OutliersMAD(x, 3.5), t)
+}
+
+func TestOutliersMAD_ZeroMAD(t *testing.T) {
+	x := []float64{1., 1., 1., 1., 1., 100.}
+	compareIndices([]int{5}, OutliersMAD(x, 3.5), t)
+}
+
+func TestOutliersMAD_Empty(t *testing.T) {
+	compareIndices(nil, OutliersMAD(nil, 3.5), t)
+}
+
+func TestOutliersZScore(t *testing.T) {
+	x := []float64{10., 11., 9., 10., 12., 8., 10., 11., 9., 40.}
+	compareIndices([]int{9}, OutliersZScore(x, 2.5), t)
+}
+
+func TestOutliersIQR(t *testing.T) {
+	x := []float64{-20., 1., 2., 3., 4., 5., 6., 7., 8., 30.}
+	compareIndices([]int{0, 9}, OutliersIQR(x, 1.5), t)
+}
+
+func TestOutliersGrubbs(t *testing.T) {
+	x := []float64{2.1, 2.3, 1.9, 2.0, 2.2, 2.1, 1.8, 2.0, 9.5, 2.2, 2.05, 1.95}
+	compareIndices([]int{8}, OutliersGrubbs(x, 0.05), t)
+	// the second outlier is tested once the first is removed
+	x[3] = 5.
+	compareIndices([]int{3, 8}, OutliersGrubbs(x, 0.05), t)
+	compareIndices(nil, OutliersGrubbs([]float64{1., 1., 1., 1.}, 0.05), t)
+	compareIndices(nil, OutliersGrubbs([]float64{1., 100.}, 0.05), t)
+}
+
+func TestRemoveOutliers(t *testing.T) {
+	x := []float64{1., 2., 100., 3.}
+	compareArrays([]float64{1., 2., 3.}, RemoveOutliers(x, []int{2}), t)
+	compareArrays([]float64{1., 2., 100., 3.}, x, t)
+}
+
+func TestWinsorizeOutliers(t *testing.T) {
+	x := []float64{-50., 1., 2., 100., 3.}
+	compareArrays([]float64{1., 1., 2., 3., 3.}, WinsorizeOutliers(x, []int{0, 3}), t)
+	compareArrays([]float64{-50., 1., 2., 100., 3.}, x, t)
+}
+
+func compareIndices(want, got []int, t *testing.T) {
+	if len(want) != len(got) {
+		t.Fatalf("Expected indices=%v, got=%v", want, got)
+	}
+	for i := 0; i < len(want); i++ {
+		if got[i] != want[i] {
+			t.Errorf("Expected index at %d=%d, got=%d", i, want[i], got[i])
+		}
+	}
+}
@@ -0,0 +1,48 @@
+package gostat
+
+import (
+	"math"
+)
+
+// MovCovariance returns moving covariance, a slice of local k-point sample
+// covariances of x and y, where each covariance is calculated over the same
+// sliding window of length k across both series. The windows are selected
+// the same way as by MovApply and keep x and y aligned: OmitNaNs omits the
+// pairs where either value is NaN or infinite, and NaNSkip removes them
+// from each window. Windows with fewer than two pairs yield NaN.
+func MovCovariance(x, y []float64, k int, opts WindowOpts) []float64 {
+	return movApplyPair(x, y, k, opts, covariance)
+}
+
+// MovCorrelation returns moving correlation, a slice of local k-point
+// Pearson correlations of x and y over windows selected the same way as by
+// MovCovariance.
+func MovCorrelation(x, y []float64, k int, opts WindowOpts) []float64 {
+	return movApplyPair(x, y, k, opts, correlation)
+}
+
+// MovBeta returns moving beta, a slice of local k-point slopes of x on y,
+// the covariance of x and y divided by the variance of y, over windows
+// selected the same way as by MovCovariance. With x the returns of an asset
+// and y those of a hedge, it is the rolling hedge ratio.
+func MovBeta(x, y []float64, k int, opts WindowOpts) []float64 {
+	return movApplyPair(x, y, k, opts, func(wx, wy, weights []float64) float64 {
+		return covariance(wx, wy, weights) / Variance(wy, weights, VarianceTwoPass)
+	})
+}
+
+// movApplyPair returns the values of fn over the sliding windows of length k
+// across the aligned series x and y.
+func movApplyPair(x, y []float64, k int, opts WindowOpts, fn func(x, y, weights []float64) float64) []float64 {
+	it := NewAlignedWindowIter([][]float64{x, y}, k, opts)
+	rets := make([]float64, 0, it.Len())
+	for it.Next() {
+		w := it.Windows()
+		if it.Padded() || len(w[0]) < 2 {
+			rets = append(rets, math.NaN())
+			continue
+		}
+		rets = append(rets, fn(w[0], w[1], it.Weights()))
+	}
+	return rets
+}
@@ -0,0 +1,50 @@
+package gostat
+
+import (
+	"github.com/gonum/stat"
+	"math"
+	"testing"
+)
+
+func TestMovCovariance(t *testing.T) {
+	x := []float64{1., 2., 4., 3., 5., 7.}
+	y := []float64{2., 1., 3., 5., 4., 6.}
+	opts := WindowOpts{Trailing: true, FullWindow: true}
+	c := MovCovariance(x, y, 3, opts)
+	want := make([]float64, 0, 4)
+	for i := 3; i <= len(x); i++ {
+		want = append(want, stat.Covariance(x[i-3:i], y[i-3:i], nil))
+	}
+	compareArrays(want, c, t)
+}
+
+func TestMovCorrelation(t *testing.T) {
+	x := []float64{1., 2., 3., 4., 5.}
+	y := []float64{2., 4., 6., 8., 10.}
+	c := MovCorrelation(x, y, 3, WindowOpts{Trailing: true})
+	compareArrays([]float64{math.NaN(), 1., 1., 1., 1.}, c, t)
+	c = MovCorrelation(x, []float64{5., 4., 3., 2., 1.}, 3, WindowOpts{})
+	compareArrays([]float64{-1., -1., -1., -1., -1.}, c, t)
+}
+
+func TestMovBeta(t *testing.T) {
+	y := []float64{0.01, -0.02, 0.015, 0.03, -0.01}
+	x := make([]float64, len(y))
+	for i := 0; i < len(y); i++ {
+		x[i] = 1.5*y[i] + 0.001
+	}
+	b := MovBeta(x, y, 3, WindowOpts{Trailing: true, FullWindow: true})
+	compareArrays([]float64{1.5, 1.5, 1.5}, b, t)
+}
+
+func TestMovCovariance_NaNSkip(t *testing.T) {
+	x := []float64{1., 2., math.NaN(), 4., 5.}
+	y := []float64{1., 3., 3., math.NaN(), 9.}
+	opts := WindowOpts{Trailing: true, FullWindow: true, NaNPolicy: NaNSkip}
+	c := MovCovariance(x, y, 3, opts)
+	compareArrays([]float64{1., math.NaN(), math.NaN()}, c, t)
+
+	opts = WindowOpts{Trailing: true, OmitNaNs: true, Pad: true, FullWindow: true}
+	c = MovCovariance(x, y, 3, opts)
+	compareArrays([]float64{math.NaN(), math.NaN(), math.NaN(), math.NaN(), stat.Covariance([]float64{1., 2., 5.}, []float64{1., 3., 9.}, nil)}, c, t)
+}
@@ -0,0 +1,136 @@
+package gostat
+
+import (
+	"github.com/gonum/matrix/mat64"
+	"math"
+	"sort"
+)
+
+// PrincipalComponents is the result of a principal component analysis.
+type PrincipalComponents struct {
+	// Eigenvalues are the variances of the principal components, from the
+	// largest to the smallest.
+	Eigenvalues []float64
+	// Loadings[j] holds the weights of each series in the j-th principal
+	// component, a unit length eigenvector of the covariance matrix.
+	Loadings [][]float64
+	// Scores[j] is the series of the j-th principal component, the centered
+	// series projected onto the j-th loadings.
+	Scores [][]float64
+}
+
+// PCA returns the principal component analysis of aligned series, where
+// series[j][i] is the i-th observation of the j-th series, for example a
+// panel of returns. The principal components are the eigenvectors of the
+// covariance matrix of the series, each oriented so that its largest weight
+// is positive.
+func PCA(series [][]float64) (PrincipalComponents, error) {
+	var pc PrincipalComponents
+	if err := checkPanel(series, 2); err != nil {
+		return pc, err
+	}
+	p, n := len(series), len(series[0])
+	mean, cov := meanCov(series, nil)
+
+	var eig mat64.EigenSym
+	if !eig.Factorize(cov, true) {
+		return pc, ErrNotConverged
+	}
+	values := eig.Values(nil)
+	var vectors mat64.Dense
+	vectors.EigenvectorsSym(&eig)
+
+	order := make([]int, p)
+	for j := 0; j < p; j++ {
+		order[j] = j
+	}
+	sort.Sort(sort.Reverse(byValue{order, values}))
+
+	pc.Eigenvalues = make([]float64, p)
+	pc.Loadings = make([][]float64, p)
+	pc.Scores = make([][]float64, p)
+	for j, c := range order {
+		pc.Eigenvalues[j] = math.Max(values[c], 0)
+		loadings := make([]float64, p)
+		var largest float64
+		for a := 0; a < p; a++ {
+			loadings[a] = vectors.At(a, c)
+			if math.Abs(loadings[a]) > math.Abs(largest) {
+				largest = loadings[a]
+			}
+		}
+		if largest < 0 {
+			for a := 0; a < p; a++ {
+				loadings[a] = -loadings[a]
+			}
+		}
+		scores := make([]float64, n)
+		for i := 0; i < n; i++ {
+			for a := 0; a < p; a++ {
+				scores[i] += loadings[a] * (series[a][i] - mean[a])
+			}
+		}
+		pc.Loadings[j] = loadings
+		pc.Scores[j] = scores
+	}
+	return pc, nil
+}
+
+// AbsorptionRatio returns the fraction of the total variance of aligned
+// series explained by the first n principal components. Applied to a panel
+// of asset returns, a high ratio means the assets are tightly coupled and
+// shocks propagate more broadly.
+func AbsorptionRatio(series [][]float64, n int) (float64, error) {
+	pc, err := PCA(series)
+	if err != nil {
+		return math.NaN(), err
+	}
+	if n < 1 || n > len(pc.Eigenvalues) {
+		return math.NaN(), ErrInvalidParameter
+	}
+	var absorbed, total float64
+	for j, v := range pc.Eigenvalues {
+		if j < n {
+			absorbed += v
+		}
+		total += v
+	}
+	return absorbed / total, nil
+}
+
+// MovAbsorptionRatio returns moving absorption ratio, a slice of the
+// AbsorptionRatio of the first n principal components calculated over
+// sliding windows of length k across aligned series. The windows are
+// selected the same way as by RollingWindow, and windows with fewer than two
+// observations yield NaN. Weights and NaN handling options are ignored.
+func MovAbsorptionRatio(series [][]float64, k, n int, opts WindowOpts) ([]float64, error) {
+	if err := checkPanel(series, 1); err != nil {
+		return nil, err
+	}
+	if k < 1 {
+		return nil, ErrInvalidWindow
+	}
+	if n < 1 || n > len(series) {
+		return nil, ErrInvalidParameter
+	}
+
+	size := len(series[0])
+	it := newWindowIterOpts(size, size, k, opts, nil)
+	rets := make([]float64, 0, it.len())
+	window := make([][]float64, len(series))
+	for it.next() {
+		if it.pad {
+			rets = append(rets, math.NaN())
+			continue
+		}
+		for j := 0; j < len(series); j++ {
+			window[j] = series[j][it.start:it.end]
+		}
+		ratio, err := AbsorptionRatio(window, n)
+		if err != nil {
+			ratio = math.NaN()
+		}
+		rets = append(rets, ratio)
+	}
+	return rets, nil
+}
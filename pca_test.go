@@ -0,0 +1,77 @@
+package gostat
+
+import (
+	"math"
+	"testing"
+)
+
+func TestPCA(t *testing.T) {
+	x := []float64{1., 2., 3., 4., 5.}
+	y := []float64{2., 4., 6., 8., 10.}
+	pc, err := PCA([][]float64{x, y})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	compareArrays([]float64{12.5, 0.}, pc.Eigenvalues, t)
+	compareArrays([]float64{1. / math.Sqrt(5.), 2. / math.Sqrt(5.)}, pc.Loadings[0], t)
+	compareArrays([]float64{-4.4721, -2.2361, 0., 2.2361, 4.4721}, pc.Scores[0], t)
+	compareArrays([]float64{0., 0., 0., 0., 0.}, pc.Scores[1], t)
+}
+
+func TestPCA_Uncorrelated(t *testing.T) {
+	x := []float64{1., -1., 1., -1.}
+	y := []float64{3., 3., -3., -3.}
+	pc, err := PCA([][]float64{x, y})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	compareArrays([]float64{12., 1.3333}, pc.Eigenvalues, t)
+	compareArrays([]float64{0., 1.}, pc.Loadings[0], t)
+	compareArrays([]float64{1., 0.}, pc.Loadings[1], t)
+}
+
+func TestPCA_Errors(t *testing.T) {
+	if _, err := PCA(nil); err != ErrEmptyInput {
+		t.Errorf("Expected error=%v, got=%v", ErrEmptyInput, err)
+	}
+	if _, err := PCA([][]float64{{1., 2.}, {1.}}); err != ErrLengthMismatch {
+		t.Errorf("Expected error=%v, got=%v", ErrLengthMismatch, err)
+	}
+	if _, err := PCA([][]float64{{1.}, {1.}}); err != ErrEmptyInput {
+		t.Errorf("Expected error=%v, got=%v", ErrEmptyInput, err)
+	}
+}
+
+func TestAbsorptionRatio(t *testing.T) {
+	x := []float64{1., -1., 1., -1.}
+	y := []float64{3., 3., -3., -3.}
+	ratio, err := AbsorptionRatio([][]float64{x, y}, 1)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if got, want := ratio, 0.9; !floatEquals(got, want) {
+		t.Errorf("Expected absorption ratio=%f, got=%f", want, got)
+	}
+	if _, err := AbsorptionRatio([][]float64{x, y}, 3); err != ErrInvalidParameter {
+		t.Errorf("Expected error=%v, got=%v", ErrInvalidParameter, err)
+	}
+}
+
+func TestMovAbsorptionRatio(t *testing.T) {
+	x := []float64{1., 2., 3., 4., 1., -1., 1., -1.}
+	y := []float64{2., 4., 6., 8., 3., 3., -3., -3.}
+	ratios, err := MovAbsorptionRatio([][]float64{x, y}, 4, 1, WindowOpts{Trailing: true})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if got, want := len(ratios), len(x); got != want {
+		t.Fatalf("Expected number of elements=%d, got=%d", want, got)
+	}
+	if got := ratios[0]; !math.IsNaN(got) {
+		t.Errorf("Expected absorption ratio=NaN, got=%f", got)
+	}
+	compareArrays([]float64{1., 1., 1.}, ratios[1:4], t)
+	if got, want := ratios[7], 0.9; !floatEquals(got, want) {
+		t.Errorf("Expected absorption ratio=%f, got=%f", want, got)
+	}
+}
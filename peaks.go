@@ -0,0 +1,127 @@
+package gostat
+
+import (
+	"math"
+	"sort"
+)
+
+// Peak is a local maximum of a series found by FindPeaks, or a local minimum
+// found by FindTroughs.
+type Peak struct {
+	Index int
+	// Prominence is how far the peak stands out of the surrounding series,
+	// the height of the peak above the higher of the lowest values between
+	// it and the nearest higher value on either side, or the end of the
+	// series.
+	Prominence float64
+	// LeftBase and RightBase are the indices of those lowest values.
+	LeftBase, RightBase int
+	// Width is the width of the peak at half its prominence, in samples,
+	// interpolated between neighboring values.
+	Width float64
+}
+
+// FindPeaks returns the local maxima of x in ascending order of index, as
+// found by the find_peaks function of SciPy, for example the dominant
+// frequencies of a periodogram, the modes of a density estimate or the
+// swing highs of a price series. A peak on a plateau of equal values is at
+// its middle. Peaks closer than minDistance to a higher peak are dropped
+// first, keeping the rightmost of equal peaks as SciPy does, then those
+// with a prominence below minProminence.
+func FindPeaks(x []float64, minProminence float64, minDistance int) []Peak {
+	var idx []int
+	for i := 1; i < len(x)-1; i++ {
+		if !(x[i-1] < x[i]) {
+			continue
+		}
+		j := i + 1
+		for j < len(x)-1 && x[j] == x[i] {
+			j++
+		}
+		if x[j] < x[i] {
+			idx = append(idx, (i+j-1)/2)
+		}
+		i = j - 1
+	}
+
+	if minDistance > 1 && len(idx) > 1 {
+		// like SciPy, visit the peaks from the highest, and equal peaks
+		// from the rightmost
+		order := append([]int{}, idx...)
+		sort.Stable(byValue{order, x})
+		keep := make([]bool, len(idx))
+		for i := 0; i < len(idx); i++ {
+			keep[i] = true
+		}
+		for o := len(order) - 1; o >= 0; o-- {
+			i := sort.SearchInts(idx, order[o])
+			if !keep[i] {
+				continue
+			}
+			for j := i - 1; j >= 0 && idx[i]-idx[j] < minDistance; j-- {
+				keep[j] = false
+			}
+			for j := i + 1; j < len(idx) && idx[j]-idx[i] < minDistance; j++ {
+				keep[j] = false
+			}
+		}
+		var kept []int
+		for i := 0; i < len(idx); i++ {
+			if keep[i] {
+				kept = append(kept, idx[i])
+			}
+		}
+		idx = kept
+	}
+
+	var peaks []Peak
+	for _, p := range idx {
+		pk := Peak{Index: p, LeftBase: p, RightBase: p}
+		for i := p - 1; i >= 0 && x[i] <= x[p]; i-- {
+			if x[i] < x[pk.LeftBase] {
+				pk.LeftBase = i
+			}
+		}
+		for i := p + 1; i < len(x) && x[i] <= x[p]; i++ {
+			if x[i] < x[pk.RightBase] {
+				pk.RightBase = i
+			}
+		}
+		base := math.Max(x[pk.LeftBase], x[pk.RightBase])
+		pk.Prominence = x[p] - base
+		if pk.Prominence < minProminence {
+			continue
+		}
+
+		line := x[p] - pk.Prominence/2
+		i := p
+		for i > pk.LeftBase && x[i] > line {
+			i--
+		}
+		left := float64(i)
+		if x[i] < line {
+			left += (line - x[i]) / (x[i+1] - x[i])
+		}
+		i = p
+		for i < pk.RightBase && x[i] > line {
+			i++
+		}
+		right := float64(i)
+		if x[i] < line {
+			right -= (line - x[i]) / (x[i-1] - x[i])
+		}
+		pk.Width = right - left
+		peaks = append(peaks, pk)
+	}
+	return peaks
+}
+
+// FindTroughs returns the local minima of x, found as the peaks of -x by
+// FindPeaks, such as the swing lows of a price series.
+func FindTroughs(x []float64, minProminence float64, minDistance int) []Peak {
+	neg := make([]float64, len(x))
+	for i := 0; i < len(x); i++ {
+		neg[i] = -x[i]
+	}
+	return FindPeaks(neg, minProminence, minDistance)
+}
@@ -0,0 +1,68 @@
+package gostat
+
+import (
+	"testing"
+)
+
+func TestFindPeaks(t *testing.T) {
+	x := []float64{0., 2., 1., 3., 3., 3., 0., 1., 0.5, 4., 0.}
+	peaks := FindPeaks(x, 0, 0)
+	if len(peaks) != 4 {
+		t.Fatalf("Expected 4 peaks, got=%v", peaks)
+	}
+	compareIndices([]int{1, 4, 7, 9}, peakIndices(peaks), t)
+	want := []Peak{
+		{Index: 1, Prominence: 1., LeftBase: 0, RightBase: 2, Width: 0.75},
+		{Index: 4, Prominence: 3., LeftBase: 0, RightBase: 6, Width: 3.25},
+		{Index: 7, Prominence: 0.5, LeftBase: 6, RightBase: 8, Width: 0.75},
+		{Index: 9, Prominence: 4., LeftBase: 6, RightBase: 10, Width: 1.0714},
+	}
+	for i, p := range peaks {
+		if p.LeftBase != want[i].LeftBase || p.RightBase != want[i].RightBase {
+			t.Errorf("Expected bases of peak %d=(%d, %d), got=(%d, %d)", p.Index, want[i].LeftBase, want[i].RightBase, p.LeftBase, p.RightBase)
+		}
+		if !floatEquals(p.Prominence, want[i].Prominence) || !floatEquals(p.Width, want[i].Width) {
+			t.Errorf("Expected peak %d prominence=%f width=%f, got=%f, %f", p.Index, want[i].Prominence, want[i].Width, p.Prominence, p.Width)
+		}
+	}
+
+	compareIndices([]int{4, 9}, peakIndices(FindPeaks(x, 1.5, 0)), t)
+	compareIndices([]int{1, 4, 9}, peakIndices(FindPeaks(x, 0, 3)), t)
+}
+
+func TestFindPeaks_Shoulder(t *testing.T) {
+	// the width stops at the shoulder level with half the prominence
+	peaks := FindPeaks([]float64{0., 1., 1., 2., 1., 1., 0.}, 0, 0)
+	if len(peaks) != 1 {
+		t.Fatalf("Expected 1 peak, got=%v", peaks)
+	}
+	if got, want := peaks[0].Width, 2.; !floatEquals(got, want) {
+		t.Errorf("Expected width=%f, got=%f", want, got)
+	}
+	peaks = FindPeaks([]float64{0., 1., 3., 3., 3., 1., 0.}, 0, 0)
+	if got, want := peakIndices(peaks), []int{3}; len(got) != 1 || got[0] != want[0] {
+		t.Fatalf("Expected peak indices=%v, got=%v", want, got)
+	}
+	if got, want := peaks[0].Width, 3.5; !floatEquals(got, want) {
+		t.Errorf("Expected plateau width=%f, got=%f", want, got)
+	}
+}
+
+func TestFindPeaks_EqualDistance(t *testing.T) {
+	x := []float64{0., 2., 0., 2., 0., 1., 0.}
+	compareIndices([]int{3}, peakIndices(FindPeaks(x, 0, 3)), t)
+}
+
+func TestFindTroughs(t *testing.T) {
+	x := []float64{5., 3., 4., 1., 2., 2.5, 0.5, 6.}
+	compareIndices([]int{1, 3, 6}, peakIndices(FindTroughs(x, 0, 0)), t)
+	compareIndices([]int{3, 6}, peakIndices(FindTroughs(x, 1.5, 0)), t)
+}
+
+func peakIndices(peaks []Peak) []int {
+	idx := make([]int, len(peaks))
+	for i := 0; i < len(peaks); i++ {
+		idx[i] = peaks[i].Index
+	}
+	return idx
+}
@@ -0,0 +1,116 @@
+package gostat
+
+import (
+	"math"
+)
+
+// Performance holds the annualized return and risk statistics of a series of
+// returns returned by PerformanceSummary, the numbers of a tear sheet.
+type Performance struct {
+	// Periods is the number of returns.
+	Periods int
+	// TotalReturn is the compounded return over all the periods, and CAGR
+	// the compound annual growth rate.
+	TotalReturn, CAGR float64
+	// Volatility is the annualized standard deviation of the returns.
+	Volatility float64
+	// Sharpe and Sortino are the annualized Sharpe and Sortino ratios, see
+	// SharpeRatio and SortinoRatio.
+	Sharpe, Sortino float64
+	// MaxDrawdown is the maximum drawdown of the compounded returns, and
+	// Calmar the ratio of CAGR to it, NaN when there is no drawdown.
+	MaxDrawdown, Calmar float64
+	// Skewness and Kurtosis are the sample skewness and excess kurtosis of
+	// the returns, see Describe.
+	Skewness, Kurtosis float64
+	// VaR is the one period historical value at risk at 95% confidence,
+	// the loss exceeded by 5% of the returns as a positive fraction.
+	VaR float64
+	// HitRatio is the fraction of positive returns.
+	HitRatio float64
+}
+
+// PerformanceSummary returns the annualized performance statistics of simple
+// returns x in a single Performance, where periodicity is the number of
+// periods per year, 252 for daily returns, and riskFree the risk-free rate
+// per period, which is also the target of the Sortino ratio. The statistics
+// are NaN when x is empty or contains NaN, and those which need more
+// returns, such as the ratios for a single return, are NaN too.
+func PerformanceSummary(x []float64, periodicity, riskFree float64) Performance {
+	nan := math.NaN()
+	p := Performance{
+		Periods: len(x), TotalReturn: nan, CAGR: nan, Volatility: nan,
+		Sharpe: nan, Sortino: nan, MaxDrawdown: nan, Calmar: nan,
+		Skewness: nan, Kurtosis: nan, VaR: nan, HitRatio: nan,
+	}
+	if len(x) == 0 || hasNaN(x) {
+		return p
+	}
+	s := Describe(x, nil)
+	equity := make([]float64, len(x)+1)
+	equity[0] = 1
+	var hits int
+	for i := 0; i < len(x); i++ {
+		equity[i+1] = equity[i] * (1 + x[i])
+		if x[i] > 0 {
+			hits++
+		}
+	}
+	growth := equity[len(x)]
+	p.TotalReturn = growth - 1
+	p.CAGR = math.Pow(growth, periodicity/float64(len(x))) - 1
+	p.Volatility = s.StdDev * math.Sqrt(periodicity)
+	p.Sharpe = SharpeRatio(x, riskFree, periodicity)
+	p.Sortino = SortinoRatio(x, riskFree, periodicity)
+	p.MaxDrawdown, _, _ = MaxDrawdown(equity)
+	if p.MaxDrawdown > 0 {
+		p.Calmar = p.CAGR / p.MaxDrawdown
+	}
+	p.Skewness, p.Kurtosis = s.Skewness, s.Kurtosis
+	p.VaR = -s.P5
+	p.HitRatio = float64(hits) / float64(len(x))
+	return p
+}
+
+// PricePerformanceSummary returns the PerformanceSummary of the simple
+// returns of prices.
+func PricePerformanceSummary(prices []float64, periodicity, riskFree float64) Performance {
+	return PerformanceSummary(SimpleReturns(prices), periodicity, riskFree)
+}
+
+// RollingPerformance holds the performance statistics of each trailing
+// window of returns returned by MovPerformanceSummary, one slice for each
+// statistic of Performance, aligned with the returns.
+type RollingPerformance struct {
+	TotalReturn, CAGR   []float64
+	Volatility          []float64
+	Sharpe, Sortino     []float64
+	MaxDrawdown, Calmar []float64
+	Skewness, Kurtosis  []float64
+	VaR                 []float64
+	HitRatio            []float64
+}
+
+// MovPerformanceSummary returns the PerformanceSummary of simple returns x
+// over trailing windows of length k, such as the trailing 252 days of daily
+// returns, to follow the health of a strategy over time. The i-th value of
+// each slice is calculated over the k returns up to and including x[i], and
+// is NaN for the first k-1 returns, which have no full window.
+func MovPerformanceSummary(x []float64, k int, periodicity, riskFree float64) RollingPerformance {
+	opts := WindowOpts{Trailing: true, FullWindow: true, Pad: true}
+	m := MovApplyMulti(x, k, opts, 11, func(window, _, dst []float64) {
+		p := PerformanceSummary(window, periodicity, riskFree)
+		dst[0], dst[1], dst[2] = p.TotalReturn, p.CAGR, p.Volatility
+		dst[3], dst[4] = p.Sharpe, p.Sortino
+		dst[5], dst[6] = p.MaxDrawdown, p.Calmar
+		dst[7], dst[8] = p.Skewness, p.Kurtosis
+		dst[9], dst[10] = p.VaR, p.HitRatio
+	})
+	return RollingPerformance{
+		TotalReturn: m[0], CAGR: m[1], Volatility: m[2],
+		Sharpe: m[3], Sortino: m[4],
+		MaxDrawdown: m[5], Calmar: m[6],
+		Skewness: m[7], Kurtosis: m[8],
+		VaR: m[9], HitRatio: m[10],
+	}
+}
@@ -0,0 +1,86 @@
+package gostat
+
+import (
+	"math"
+	"testing"
+)
+
+func TestPerformanceSummary(t *testing.T) {
+	x := []float64{0.1, -0.05, 0.02, -0.1, 0.05}
+	p := PerformanceSummary(x, 12, 0.)
+	if got, want := p.Periods, 5; got != want {
+		t.Errorf("Expected Periods=%d, got=%d", want, got)
+	}
+	cases := []struct {
+		name string
+		got  float64
+		want float64
+	}{
+		{"TotalReturn", p.TotalReturn, 0.0072755},
+		{"CAGR", p.CAGR, 0.0175497},
+		{"Volatility", p.Volatility, 0.2756},
+		{"Sharpe", p.Sharpe, 0.1742},
+		{"Sortino", p.Sortino, SortinoRatio(x, 0., 12)},
+		{"MaxDrawdown", p.MaxDrawdown, 0.1279},
+		{"Calmar", p.Calmar, 0.1372},
+		{"Skewness", p.Skewness, Describe(x, nil).Skewness},
+		{"Kurtosis", p.Kurtosis, Describe(x, nil).Kurtosis},
+		{"VaR", p.VaR, 0.09},
+		{"HitRatio", p.HitRatio, 0.6},
+	}
+	for _, c := range cases {
+		if !floatEquals(c.got, c.want) {
+			t.Errorf("Expected %s=%f, got=%f", c.name, c.want, c.got)
+		}
+	}
+}
+
+func TestPricePerformanceSummary(t *testing.T) {
+	prices := []float64{100., 110., 104.5, 106.59, 95.931, 100.72755}
+	p := PricePerformanceSummary(prices, 12, 0.)
+	if got, want := p.TotalReturn, 0.0072755; !floatEquals(got, want) {
+		t.Errorf("Expected TotalReturn=%f, got=%f", want, got)
+	}
+	if got, want := p.MaxDrawdown, 0.1279; !floatEquals(got, want) {
+		t.Errorf("Expected MaxDrawdown=%f, got=%f", want, got)
+	}
+}
+
+func TestPerformanceSummary_NoDrawdown(t *testing.T) {
+	p := PerformanceSummary([]float64{0.01, 0.02, 0.01}, 252, 0.)
+	if p.MaxDrawdown != 0 || !math.IsNaN(p.Calmar) {
+		t.Errorf("Expected MaxDrawdown=0 and Calmar=NaN, got=%f and %f", p.MaxDrawdown, p.Calmar)
+	}
+	if got, want := p.HitRatio, 1.; got != want {
+		t.Errorf("Expected HitRatio=%f, got=%f", want, got)
+	}
+}
+
+func TestPerformanceSummary_Empty(t *testing.T) {
+	for _, x := range [][]float64{nil, {0.01, math.NaN()}} {
+		p := PerformanceSummary(x, 252, 0.)
+		if !math.IsNaN(p.CAGR) || !math.IsNaN(p.Sharpe) || !math.IsNaN(p.HitRatio) {
+			t.Errorf("Expected NaN statistics for x=%v, got=%+v", x, p)
+		}
+	}
+}
+
+func TestMovPerformanceSummary(t *testing.T) {
+	x := []float64{0.1, -0.05, 0.02, -0.1, 0.05, 0.03}
+	r := MovPerformanceSummary(x, 5, 12, 0.)
+	if got, want := len(r.CAGR), len(x); got != want {
+		t.Fatalf("Expected %d values, got=%d", want, got)
+	}
+	nan := math.NaN()
+	compareArrays([]float64{nan, nan, nan, nan, 0.0072755, -0.056824}, r.TotalReturn, t)
+	compareArrays([]float64{nan, nan, nan, nan, 0.6, 0.6}, r.HitRatio, t)
+	for i := 4; i < len(x); i++ {
+		p := PerformanceSummary(x[i-4:i+1], 12, 0.)
+		if got, want := r.Sharpe[i], p.Sharpe; !floatEquals(got, want) {
+			t.Errorf("Expected Sharpe at %d=%f, got=%f", i, want, got)
+		}
+		if got, want := r.MaxDrawdown[i], p.MaxDrawdown; !floatEquals(got, want) {
+			t.Errorf("Expected MaxDrawdown at %d=%f, got=%f", i, want, got)
+		}
+	}
+}
@@ -0,0 +1,64 @@
+package gostat
+
+import (
+	"math"
+)
+
+// psiFloor is the fraction assumed for a bin without any observation, which
+// keeps the logarithm of the PSI finite.
+const psiFloor = 1e-4
+
+// StabilityIndex is the population stability index between an expected and
+// an actual distribution, returned by PSI.
+type StabilityIndex struct {
+	// PSI is the sum of the contributions of all bins. By convention a PSI
+	// below 0.1 means no significant shift, up to 0.25 a moderate shift,
+	// and above it a major shift.
+	PSI float64
+	// Edges are the ascending boundaries between the bins, where the k-th
+	// bin holds the values above edge k-1 and at most edge k, and the first
+	// and the last bins are open ended.
+	Edges []float64
+	// Expected and Actual hold the fraction of each sample in each bin.
+	Expected, Actual []float64
+	// Contributions holds the term of each bin,
+	// (actual-expected)*ln(actual/expected).
+	Contributions []float64
+}
+
+// PSI returns the population stability index of the actual sample against
+// the expected one, such as the scores of a model in production against its
+// development sample, or the values of one of its characteristics, to detect
+// a drift of their distribution. Following the usual convention the bins are
+// bounded by the bins-quantiles of the expected sample, deciles for 10 bins,
+// so that each holds about the same fraction of it, with bounds shared by
+// tied values merged, and an empty bin counts as a fraction of 0.0001. NaN
+// values are ignored, and the PSI is NaN when either sample has no other
+// values or bins is smaller than one.
+func PSI(expected, actual []float64, bins int) StabilityIndex {
+	e, a := sortedFinite(expected), sortedFinite(actual)
+	if len(e) == 0 || len(a) == 0 || bins < 1 {
+		return StabilityIndex{PSI: math.NaN()}
+	}
+	var edges []float64
+	for k := 1; k < bins; k++ {
+		q := quantileSorted(e, float64(k)/float64(bins), QuantileLinear)
+		if len(edges) == 0 || q > edges[len(edges)-1] {
+			edges = append(edges, q)
+		}
+	}
+
+	si := StabilityIndex{
+		Edges:    edges,
+		Expected: binFractions(e, edges, true),
+		Actual:   binFractions(a, edges, true),
+	}
+	si.Contributions = make([]float64, len(si.Expected))
+	for k := 0; k < len(si.Expected); k++ {
+		pe := math.Max(si.Expected[k], psiFloor)
+		pa := math.Max(si.Actual[k], psiFloor)
+		si.Contributions[k] = (pa - pe) * math.Log(pa/pe)
+		si.PSI += si.Contributions[k]
+	}
+	return si
+}
@@ -0,0 +1,32 @@
+package gostat
+
+import (
+	"math"
+	"testing"
+)
+
+func TestPSI(t *testing.T) {
+	expected := []float64{1., 2., 3., 4., 5., 6., 7., 8.}
+	actual := []float64{3., 4., 6., 7., 7., 8., 9., math.NaN()}
+	si := PSI(expected, actual, 4)
+	compareArrays([]float64{2.75, 4.5, 6.25}, si.Edges, t)
+	compareArrays([]float64{0.25, 0.25, 0.25, 0.25}, si.Expected, t)
+	compareArrays([]float64{0., 0.2857, 0.1429, 0.5714}, si.Actual, t)
+	compareArrays([]float64{1.9552, 0.0048, 0.0600, 0.2657}, si.Contributions, t)
+	if got, want := si.PSI, 2.2857; !floatEquals(got, want) {
+		t.Errorf("Expected PSI=%f, got=%f", want, got)
+	}
+	if got := PSI(expected, expected, 4).PSI; !floatEquals(got, 0) {
+		t.Errorf("Expected PSI=0, got=%f", got)
+	}
+}
+
+func TestPSI_Ties(t *testing.T) {
+	si := PSI([]float64{1., 1., 1., 1., 2., 3.}, []float64{1., 2., 2., 3.}, 4)
+	compareArrays([]float64{1., 1.75}, si.Edges, t)
+	compareArrays([]float64{0.6667, 0., 0.3333}, si.Expected, t)
+	compareArrays([]float64{0.25, 0., 0.75}, si.Actual, t)
+	if got := PSI(nil, []float64{1.}, 10).PSI; !math.IsNaN(got) {
+		t.Errorf("Expected PSI=NaN, got=%f", got)
+	}
+}
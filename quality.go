@@ -0,0 +1,83 @@
+package gostat
+
+import (
+	"math"
+)
+
+// QualityReport lists the values of a series that may need attention
+// before calculating statistics from it, as found by DataQuality.
+type QualityReport struct {
+	// Count is the number of values in the series.
+	Count int
+	// NaN, PosInf and NegInf are the indices of the NaN, positive infinite
+	// and negative infinite values.
+	NaN, PosInf, NegInf []int
+	// Zeros are the indices of the values exactly equal to zero.
+	Zeros []int
+	// Repeated are the indices of the values equal to the value before
+	// them, which may indicate stale data in a feed.
+	Repeated []int
+}
+
+// Finite returns the number of values in the series that are neither NaN
+// nor infinite.
+func (r QualityReport) Finite() int {
+	return r.Count - len(r.NaN) - len(r.PosInf) - len(r.NegInf)
+}
+
+// Clean reports whether the series has no NaN or infinite values.
+func (r QualityReport) Clean() bool {
+	return r.Finite() == r.Count
+}
+
+// DataQuality returns a report of the NaN, infinite, zero and repeated
+// values of x, to audit a series before calculating statistics from it.
+func DataQuality(x []float64) QualityReport {
+	r := QualityReport{Count: len(x)}
+	for i := 0; i < len(x); i++ {
+		switch {
+		case math.IsNaN(x[i]):
+			r.NaN = append(r.NaN, i)
+		case math.IsInf(x[i], 1):
+			r.PosInf = append(r.PosInf, i)
+		case math.IsInf(x[i], -1):
+			r.NegInf = append(r.NegInf, i)
+		case x[i] == 0:
+			r.Zeros = append(r.Zeros, i)
+		}
+		if i > 0 && x[i] == x[i-1] {
+			r.Repeated = append(r.Repeated, i)
+		}
+	}
+	return r
+}
+
+// Run is a run of consecutive values given as the half-open index range
+// [Start, End) into a series.
+type Run struct {
+	Start, End int
+}
+
+// Len returns the number of values in the run.
+func (r Run) Len() int {
+	return r.End - r.Start
+}
+
+// Flatlines returns the runs of at least minLen consecutive values of x
+// which differ from the first value of the run by at most tolerance, such
+// as the repeated prices of a frozen market data feed. A tolerance of 0
+// finds runs of identical values. NaN values end a run.
+func Flatlines(x []float64, minLen int, tolerance float64) []Run {
+	var runs []Run
+	start := 0
+	for i := 1; i <= len(x); i++ {
+		if i < len(x) && math.Abs(x[i]-x[start]) <= tolerance {
+			continue
+		}
+		if i-start >= minLen && i-start > 1 {
+			runs = append(runs, Run{start, i})
+		}
+		start = i
+	}
+	return runs
+}
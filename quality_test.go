@@ -0,0 +1,58 @@
+package gostat
+
+import (
+	"math"
+	"testing"
+)
+
+func TestDataQuality(t *testing.T) {
+	x := []float64{1., math.NaN(), 0., 0., math.Inf(1), 2., 2., 2., math.Inf(-1), math.NaN()}
+	r := DataQuality(x)
+	if r.Count != len(x) {
+		t.Errorf("Expected count=%d, got=%d", len(x), r.Count)
+	}
+	compareIndices([]int{1, 9}, r.NaN, t)
+	compareIndices([]int{4}, r.PosInf, t)
+	compareIndices([]int{8}, r.NegInf, t)
+	compareIndices([]int{2, 3}, r.Zeros, t)
+	compareIndices([]int{3, 6, 7}, r.Repeated, t)
+	if got, want := r.Finite(), 6; got != want {
+		t.Errorf("Expected finite values=%d, got=%d", want, got)
+	}
+	if r.Clean() {
+		t.Errorf("Expected series not to be clean")
+	}
+}
+
+func TestDataQuality_Clean(t *testing.T) {
+	r := DataQuality([]float64{1., 2., 3.})
+	if !r.Clean() || len(r.Repeated) != 0 || len(r.Zeros) != 0 {
+		t.Errorf("Expected clean report, got=%+v", r)
+	}
+}
+
+func TestFlatlines(t *testing.T) {
+	x := []float64{1., 2., 2., 2., 2., 3., 4., 4., math.NaN(), math.NaN(), 5., 5., 5.}
+	runs := Flatlines(x, 3, 0.)
+	if len(runs) != 2 || runs[0] != (Run{1, 5}) || runs[1] != (Run{10, 13}) {
+		t.Errorf("Expected runs=[{1 5} {10 13}], got=%v", runs)
+	}
+	if got, want := runs[0].Len(), 4; got != want {
+		t.Errorf("Expected run length=%d, got=%d", want, got)
+	}
+	runs = Flatlines(x, 2, 0.)
+	if len(runs) != 3 || runs[1] != (Run{6, 8}) {
+		t.Errorf("Expected 3 runs, got=%v", runs)
+	}
+}
+
+func TestFlatlines_Tolerance(t *testing.T) {
+	x := []float64{100., 100.01, 99.99, 100.02, 101., 101.}
+	runs := Flatlines(x, 3, 0.05)
+	if len(runs) != 1 || runs[0] != (Run{0, 4}) {
+		t.Errorf("Expected runs=[{0 4}], got=%v", runs)
+	}
+	if runs := Flatlines(nil, 2, 0.); len(runs) != 0 {
+		t.Errorf("Expected no runs, got=%v", runs)
+	}
+}
@@ -0,0 +1,91 @@
+package gostat
+
+import (
+	"math"
+	"sort"
+)
+
+// QuantileMethod selects how a quantile that falls between two data points
+// is estimated. With the data sorted from lowest to highest, the p-quantile
+// lies at the fractional index h = (n-1)*p between the elements at floor(h)
+// and ceil(h).
+type QuantileMethod int
+
+const (
+	// QuantileLinear interpolates linearly between the two elements.
+	QuantileLinear QuantileMethod = iota
+	// QuantileLower selects the lower of the two elements.
+	QuantileLower
+	// QuantileHigher selects the higher of the two elements.
+	QuantileHigher
+	// QuantileNearest selects the element nearest to h, the even index
+	// when h is halfway between the two.
+	QuantileNearest
+	// QuantileMidpoint averages the two elements.
+	QuantileMidpoint
+)
+
+// Quantile returns the p-quantile of x, for p between 0 and 1, estimated
+// with the given method. It returns NaN for an empty slice or p outside of
+// the [0, 1] range.
+func Quantile(x []float64, p float64, method QuantileMethod) float64 {
+	return Quantiles(x, []float64{p}, method)[0]
+}
+
+// Quantiles returns the quantiles of x for each of the probabilities in ps,
+// estimated with the given method. The data is sorted only once.
+func Quantiles(x []float64, ps []float64, method QuantileMethod) []float64 {
+	series := append([]float64{}, x...)
+	sort.Float64s(series)
+
+	qs := make([]float64, len(ps))
+	for i := 0; i < len(ps); i++ {
+		qs[i] = quantileSorted(series, ps[i], method)
+	}
+	return qs
+}
+
+// IQR returns the interquartile range of x, the difference between the
+// third and the first quartile interpolated linearly.
+func IQR(x []float64) float64 {
+	qs := Quantiles(x, []float64{0.25, 0.75}, QuantileLinear)
+	return qs[1] - qs[0]
+}
+
+// medianSorted returns the median of sorted series.
+func medianSorted(sorted []float64) float64 {
+	k := len(sorted) / 2
+	if len(sorted)%2 == 1 {
+		return sorted[k]
+	}
+	return 0.5 * (sorted[k-1] + sorted[k])
+}
+
+// quantileSorted returns the p-quantile of sorted series.
+func quantileSorted(sorted []float64, p float64, method QuantileMethod) float64 {
+	if len(sorted) == 0 || !(p >= 0 && p <= 1) {
+		return math.NaN()
+	}
+	h := p * float64(len(sorted)-1)
+	lo := math.Floor(h)
+	frac := h - lo
+	i := int(lo)
+	if frac == 0 {
+		return sorted[i]
+	}
+
+	switch method {
+	case QuantileLower:
+		return sorted[i]
+	case QuantileHigher:
+		return sorted[i+1]
+	case QuantileNearest:
+		if frac < 0.5 || (frac == 0.5 && i%2 == 0) {
+			return sorted[i]
+		}
+		return sorted[i+1]
+	case QuantileMidpoint:
+		return 0.5 * (sorted[i] + sorted[i+1])
+	}
+	return sorted[i] + frac*(sorted[i+1]-sorted[i])
+}
@@ -0,0 +1,52 @@
+package gostat
+
+import (
+	"math"
+	"testing"
+)
+
+func TestQuantile(t *testing.T) {
+	x := []float64{4., 1., 3., 2.}
+	cases := []struct {
+		method QuantileMethod
+		p      float64
+		want   float64
+	}{
+		{QuantileLinear, 0.4, 2.2},
+		{QuantileLower, 0.4, 2.},
+		{QuantileHigher, 0.4, 3.},
+		{QuantileNearest, 0.4, 2.},
+		{QuantileNearest, 0.5, 3.},
+		{QuantileNearest, 0.6, 3.},
+		{QuantileMidpoint, 0.4, 2.5},
+		{QuantileLinear, 0., 1.},
+		{QuantileLinear, 1., 4.},
+	}
+	for _, c := range cases {
+		if got := Quantile(x, c.p, c.method); !floatEquals(got, c.want) {
+			t.Errorf("Expected quantile p=%f method=%d=%f, got=%f", c.p, c.method, c.want, got)
+		}
+	}
+}
+
+func TestQuantile_Invalid(t *testing.T) {
+	if got := Quantile(nil, 0.5, QuantileLinear); !math.IsNaN(got) {
+		t.Errorf("Expected quantile=NaN, got=%f", got)
+	}
+	if got := Quantile([]float64{1., 2.}, 1.5, QuantileLinear); !math.IsNaN(got) {
+		t.Errorf("Expected quantile=NaN, got=%f", got)
+	}
+}
+
+func TestQuantiles(t *testing.T) {
+	x := []float64{10., 1., 9., 2., 8., 3., 7., 4., 6., 5., 11.}
+	qs := Quantiles(x, []float64{0.05, 0.5, 0.95}, QuantileLinear)
+	compareArrays([]float64{1.5, 6., 10.5}, qs, t)
+}
+
+func TestIQR(t *testing.T) {
+	x := []float64{7., 1., 3., 5., 9.}
+	if got, want := IQR(x), 4.; !floatEquals(got, want) {
+		t.Errorf("Expected IQR=%f, got=%f", want, got)
+	}
+}
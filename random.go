@@ -0,0 +1,62 @@
+package gostat
+
+import (
+	"math/rand"
+	"sync"
+)
+
+var (
+	sourceMu sync.Mutex
+	source   rand.Source
+)
+
+// SetSource sets the source of random numbers shared by the stochastic
+// functions, Bootstrap, MovBootstrap, SimulateOU and RobustCovariance, when
+// they are not given a source of their own. A *rand.Rand is a source as
+// well. By default, or after SetSource(nil), each call draws from a new
+// source seeded with 1, so that repeated calls return identical results,
+// whereas calls drawing from a shared source continue its sequence. The
+// shared source is safe for concurrent use, although the results of
+// concurrent calls then depend on their order.
+func SetSource(src rand.Source) {
+	sourceMu.Lock()
+	defer sourceMu.Unlock()
+	source = src
+}
+
+// WithSeed returns a new source of random numbers seeded with seed, to pass
+// as the Source of BootstrapOpts, to SimulateOU or to SetSource for results
+// which are reproducible with a seed of choice.
+func WithSeed(seed int64) rand.Source {
+	return rand.NewSource(seed)
+}
+
+// sourceOr returns src, or the source set by SetSource when src is nil.
+func sourceOr(src rand.Source) rand.Source {
+	if src != nil {
+		return src
+	}
+	sourceMu.Lock()
+	defer sourceMu.Unlock()
+	if source == nil {
+		return rand.NewSource(1)
+	}
+	return lockedSource{source}
+}
+
+// lockedSource serializes the use of a shared source.
+type lockedSource struct {
+	src rand.Source
+}
+
+func (s lockedSource) Int63() int64 {
+	sourceMu.Lock()
+	defer sourceMu.Unlock()
+	return s.src.Int63()
+}
+
+func (s lockedSource) Seed(seed int64) {
+	sourceMu.Lock()
+	defer sourceMu.Unlock()
+	s.src.Seed(seed)
+}
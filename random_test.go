@@ -0,0 +1,45 @@
+package gostat
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestSetSource(t *testing.T) {
+	defer SetSource(nil)
+	x := []float64{3., 1., 4., 1., 5., 9., 2., 6., 5., 3., 5., 8.}
+	p := OU{Theta: 2, Mu: 1, Sigma: 0.5}
+
+	_, lo1, hi1 := Bootstrap(x, Median, 200, BootstrapOpts{})
+	_, lo2, hi2 := Bootstrap(x, Median, 200, BootstrapOpts{})
+	if lo1 != lo2 || hi1 != hi2 {
+		t.Errorf("Expected the default source to repeat, got=[%f, %f] and [%f, %f]", lo1, hi1, lo2, hi2)
+	}
+	want := SimulateOU(p, 0, 0.1, 20, WithSeed(5))
+
+	SetSource(WithSeed(5))
+	compareArrays(want, SimulateOU(p, 0, 0.1, 20, nil), t)
+	// the shared source continues its sequence
+	next := SimulateOU(p, 0, 0.1, 20, nil)
+	if next[1] == want[1] {
+		t.Errorf("Expected a new path from the shared source, got=%v", next)
+	}
+
+	SetSource(rand.New(rand.NewSource(5)))
+	compareArrays(want, SimulateOU(p, 0, 0.1, 20, nil), t)
+
+	SetSource(nil)
+	compareArrays(SimulateOU(p, 0, 0.1, 20, WithSeed(1)), SimulateOU(p, 0, 0.1, 20, nil), t)
+	if _, lo, hi := Bootstrap(x, Median, 200, BootstrapOpts{}); lo != lo1 || hi != hi1 {
+		t.Errorf("Expected interval=[%f, %f], got=[%f, %f]", lo1, hi1, lo, hi)
+	}
+}
+
+func TestWithSeed(t *testing.T) {
+	x := []float64{3., 1., 4., 1., 5., 9., 2., 6., 5., 3., 5., 8.}
+	_, lo1, hi1 := Bootstrap(x, Median, 200, BootstrapOpts{Source: WithSeed(11)})
+	_, lo2, hi2 := Bootstrap(x, Median, 200, BootstrapOpts{Source: WithSeed(11)})
+	if lo1 != lo2 || hi1 != hi2 {
+		t.Errorf("Expected equal intervals, got=[%f, %f] and [%f, %f]", lo1, hi1, lo2, hi2)
+	}
+}
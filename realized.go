@@ -0,0 +1,125 @@
+package gostat
+
+import (
+	"github.com/gonum/stat/distuv"
+	"math"
+)
+
+// RealizedVariance returns the realized variance of intraday returns, the
+// sum of their squares. With subsample k greater than 1 the returns are
+// aggregated over k consecutive periods, and the realized variances of the
+// k sparse grids starting at each of the first k returns are averaged,
+// which reduces the bias from microstructure noise in tick data. NaN is
+// returned for an empty slice.
+func RealizedVariance(returns []float64, k int) float64 {
+	if len(returns) == 0 {
+		return math.NaN()
+	}
+	if k <= 1 {
+		var sum neumaierSum
+		for i := 0; i < len(returns); i++ {
+			sum.add(returns[i] * returns[i])
+		}
+		return sum.total()
+	}
+	var avg neumaierSum
+	for offset := 0; offset < k; offset++ {
+		var sum neumaierSum
+		for i := offset; i+k <= len(returns); i += k {
+			r := Sum(returns[i : i+k])
+			sum.add(r * r)
+		}
+		avg.add(sum.total())
+	}
+	return avg.total() / float64(k)
+}
+
+// TwoScaleRealizedVariance returns the two-scale realized variance of
+// intraday returns, the subsampled RealizedVariance over k periods less the
+// noise estimated from the realized variance of all returns, with the small
+// sample adjustment of Zhang, Mykland and Ait-Sahalia. NaN is returned when
+// there are not more returns than k, or k is less than 2.
+func TwoScaleRealizedVariance(returns []float64, k int) float64 {
+	n := float64(len(returns))
+	if k < 2 || len(returns) <= k {
+		return math.NaN()
+	}
+	nbar := (n - float64(k) + 1) / float64(k)
+	tsrv := RealizedVariance(returns, k) - nbar/n*RealizedVariance(returns, 1)
+	return tsrv / (1 - nbar/n)
+}
+
+// RealizedVolatility returns the realized volatility of intraday returns,
+// the square root of their RealizedVariance with subsample k scaled by
+// periodicity, the number of days per year, or 1 for daily volatility.
+func RealizedVolatility(returns []float64, k int, periodicity float64) float64 {
+	return math.Sqrt(RealizedVariance(returns, k) * periodicity)
+}
+
+// BipowerVariation returns the bipower variation of intraday returns, the
+// scaled sum of the products of consecutive absolute returns
+//
+//	BV = pi/2 * n/(n-1) * sum(|r[i]|*|r[i-1]|)
+//
+// which, unlike RealizedVariance, is robust to jumps and estimates the
+// variance of the continuous part of the price process. NaN is returned for
+// fewer than two returns.
+func BipowerVariation(returns []float64) float64 {
+	n := len(returns)
+	if n < 2 {
+		return math.NaN()
+	}
+	var sum neumaierSum
+	for i := 1; i < n; i++ {
+		sum.add(math.Abs(returns[i]) * math.Abs(returns[i-1]))
+	}
+	return math.Pi / 2 * float64(n) / float64(n-1) * sum.total()
+}
+
+// JumpStatistic is the result of JumpTest.
+type JumpStatistic struct {
+	// RV and BV are the realized variance and the bipower variation.
+	RV, BV float64
+	// Jump is the variance of the jump component, the part of RV exceeding
+	// BV, or 0.
+	Jump float64
+	// Z is the ratio test statistic, standard normal without jumps.
+	Z float64
+	// PValue is the probability of a statistic at least as large as Z
+	// without jumps, small values indicating jumps.
+	PValue float64
+}
+
+// JumpTest tests intraday returns for jumps by comparing their realized
+// variance with their bipower variation, using the ratio statistic of
+// Barndorff-Nielsen and Shephard, as refined by Huang and Tauchen, with the
+// integrated quarticity estimated by the tripower quarticity. All values
+// are NaN for fewer than three returns.
+func JumpTest(returns []float64) JumpStatistic {
+	n := len(returns)
+	if n < 3 {
+		nan := math.NaN()
+		return JumpStatistic{RV: nan, BV: nan, Jump: nan, Z: nan, PValue: nan}
+	}
+	rv := RealizedVariance(returns, 1)
+	bv := BipowerVariation(returns)
+
+	// mu is E|Z|^(4/3) for a standard normal Z
+	mu := math.Pow(2, 2./3) * math.Gamma(7./6) / math.Gamma(0.5)
+	var sum neumaierSum
+	for i := 2; i < n; i++ {
+		sum.add(math.Pow(math.Abs(returns[i]*returns[i-1]*returns[i-2]), 4./3))
+	}
+	fn := float64(n)
+	tq := fn / math.Pow(mu, 3) * fn / (fn - 2) * sum.total()
+
+	ratio := (rv - bv) / rv
+	z := ratio / math.Sqrt((math.Pi*math.Pi/4+math.Pi-5)/fn*math.Max(1, tq/(bv*bv)))
+	return JumpStatistic{
+		RV:     rv,
+		BV:     bv,
+		Jump:   math.Max(rv-bv, 0),
+		Z:      z,
+		PValue: 1 - distuv.UnitNormal.CDF(z),
+	}
+}
@@ -0,0 +1,90 @@
+package gostat
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func TestRealizedVariance(t *testing.T) {
+	r := []float64{0.01, -0.02, 0.015, 0.005, -0.01, 0.02}
+	if got, want := RealizedVariance(r, 1), 0.00125; !floatEquals(got, want) {
+		t.Errorf("Expected realized variance=%f, got=%f", want, got)
+	}
+	// grids of pairs starting at 0 and at 1
+	want := ((-0.01*-0.01 + 0.02*0.02 + 0.01*0.01) + (-0.005*-0.005 + -0.005*-0.005)) / 2
+	if got := RealizedVariance(r, 2); !floatEquals(got, want) {
+		t.Errorf("Expected subsampled realized variance=%f, got=%f", want, got)
+	}
+	if got := RealizedVariance(nil, 1); !math.IsNaN(got) {
+		t.Errorf("Expected realized variance=NaN, got=%f", got)
+	}
+	if got, want := RealizedVolatility(r, 1, 252.), math.Sqrt(0.00125*252); !floatEquals(got, want) {
+		t.Errorf("Expected realized volatility=%f, got=%f", want, got)
+	}
+}
+
+func TestTwoScaleRealizedVariance(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	n := 23400
+	sigma := 0.01 / math.Sqrt(float64(n))
+	noise := 0.0005
+	var p float64
+	prev := rng.NormFloat64() * noise
+	returns := make([]float64, n)
+	for i := 1; i <= n; i++ {
+		p += sigma * rng.NormFloat64()
+		obs := p + noise*rng.NormFloat64()
+		returns[i-1] = obs - prev
+		prev = obs
+	}
+	rv := RealizedVariance(returns, 1)
+	tsrv := TwoScaleRealizedVariance(returns, 300)
+	// the true integrated variance is 1e-4
+	if rv < 1e-3 {
+		t.Errorf("Expected noise to dominate realized variance, got=%g", rv)
+	}
+	if math.Abs(tsrv-1e-4) > 3e-5 {
+		t.Errorf("Expected two-scale realized variance close to 1e-4, got=%g", tsrv)
+	}
+	if got := TwoScaleRealizedVariance(returns[:10], 10); !math.IsNaN(got) {
+		t.Errorf("Expected two-scale realized variance=NaN, got=%f", got)
+	}
+}
+
+func simulatedReturns(n int, seed int64) []float64 {
+	rng := rand.New(rand.NewSource(seed))
+	sigma := 0.01 / math.Sqrt(float64(n))
+	returns := make([]float64, n)
+	for i := 0; i < n; i++ {
+		returns[i] = sigma * rng.NormFloat64()
+	}
+	return returns
+}
+
+func TestBipowerVariation(t *testing.T) {
+	r := []float64{0.01, -0.02, 0.015}
+	want := math.Pi / 2 * 3 / 2 * (0.01*0.02 + 0.02*0.015)
+	if got := BipowerVariation(r); !floatEquals(got, want) {
+		t.Errorf("Expected bipower variation=%f, got=%f", want, got)
+	}
+	returns := simulatedReturns(5000, 1)
+	if got, want := BipowerVariation(returns), RealizedVariance(returns, 1); math.Abs(got-want) > 0.1*want {
+		t.Errorf("Expected bipower variation close to %g without jumps, got=%g", want, got)
+	}
+}
+
+func TestJumpTest(t *testing.T) {
+	returns := simulatedReturns(1000, 2)
+	if s := JumpTest(returns); s.PValue < 0.01 {
+		t.Errorf("Expected no jump, got=%+v", s)
+	}
+	returns[500] += 0.01
+	s := JumpTest(returns)
+	if s.PValue > 0.001 || s.Jump <= 0 {
+		t.Errorf("Expected jump, got=%+v", s)
+	}
+	if s := JumpTest(returns[:2]); !math.IsNaN(s.Z) {
+		t.Errorf("Expected NaN statistic, got=%+v", s)
+	}
+}
@@ -0,0 +1,51 @@
+package gostat
+
+import (
+	"math"
+)
+
+// MovDispersion returns moving cross-sectional dispersion of a panel of
+// aligned return series, where series[j][i] is the i-th return of the j-th
+// asset: the standard deviation of the returns of all the assets at each
+// observation, averaged over sliding windows of length k selected by opts
+// as by MovMean. High dispersion marks markets where stock picking pays off,
+// low dispersion markets driven by a common factor. Cross-sections with
+// fewer than two returns that are not NaN are NaN, and are skipped by the
+// average when the NaNSkip policy is set in opts.
+func MovDispersion(series [][]float64, k int, opts WindowOpts) []float64 {
+	dispersion := CrossSection(series, func(values []float64) float64 {
+		return StdDev(values, nil, VarianceTwoPass)
+	})
+	return MovMean(dispersion, k, opts)
+}
+
+// MovAverageCorrelation returns moving average pairwise correlation of a
+// panel of aligned return series, the mean of the k-point correlations of
+// every pair of series over windows selected the same way as by
+// MovCorrelation, a gauge of how much the assets move together, which
+// typically rises in market stress. Pairs with a NaN correlation in a
+// window, such as a series that is constant over it, are left out of its
+// average, which is NaN when no pair is left.
+func MovAverageCorrelation(series [][]float64, k int, opts WindowOpts) []float64 {
+	checkSeries(series)
+	var sums, counts []float64
+	for a := 0; a < len(series); a++ {
+		for b := a + 1; b < len(series); b++ {
+			corr := MovCorrelation(series[a], series[b], k, opts)
+			if sums == nil {
+				sums = make([]float64, len(corr))
+				counts = make([]float64, len(corr))
+			}
+			for i := 0; i < len(corr); i++ {
+				if !math.IsNaN(corr[i]) {
+					sums[i] += corr[i]
+					counts[i]++
+				}
+			}
+		}
+	}
+	for i := 0; i < len(sums); i++ {
+		sums[i] /= counts[i]
+	}
+	return sums
+}
@@ -0,0 +1,30 @@
+package gostat
+
+import (
+	"math"
+	"testing"
+)
+
+func TestMovDispersion(t *testing.T) {
+	series := [][]float64{
+		{0.01, 0.02, -0.01, 0.03},
+		{0.03, 0.02, 0.01, -0.01},
+		{0.02, 0.02, math.NaN(), 0.01},
+	}
+	m := MovDispersion(series, 2, WindowOpts{Trailing: true, FullWindow: true, Pad: true})
+	compareArrays([]float64{math.NaN(), 0.005, 0.0071, 0.0171}, m, t)
+}
+
+func TestMovAverageCorrelation(t *testing.T) {
+	series := [][]float64{
+		{1., 2., 3., 4., 5., 6.},
+		{2., 4., 6., 8., 10., 12.},
+		{6., 5., 4., 3., 2., 1.},
+	}
+	// two pairs perfectly anticorrelated and one perfectly correlated
+	m := MovAverageCorrelation(series, 3, WindowOpts{Trailing: true, FullWindow: true})
+	compareArrays([]float64{-0.3333, -0.3333, -0.3333, -0.3333}, m, t)
+
+	series = append(series, []float64{1., 1., 1., 1., 1., 1.})
+	compareArrays(m, MovAverageCorrelation(series, 3, WindowOpts{Trailing: true, FullWindow: true}), t)
+}
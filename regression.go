@@ -0,0 +1,186 @@
+package gostat
+
+import (
+	"github.com/gonum/matrix/mat64"
+	"math"
+	"sort"
+)
+
+const (
+	quantileMaxIter = 1000
+	quantileTol     = 1e-9
+)
+
+// QuantileRegression returns the coefficients of the linear regression of y
+// on the aligned predictor series x for the conditional p-quantile of y
+// instead of its conditional mean, the intercept first followed by one slope
+// for each series in x, where x[j][i] is the i-th observation of the j-th
+// predictor. With p of 0.5 it is the least absolute deviations regression,
+// fitting the conditional median, which is far less sensitive to outlying
+// values of y than least squares.
+//
+// The coefficients minimize the pinball loss of the fitted values, see
+// PinballLoss, found by iteratively reweighted least squares starting from
+// the least squares fit.
+func QuantileRegression(y []float64, x [][]float64, p float64) ([]float64, error) {
+	if !(p > 0 && p < 1) {
+		return nil, ErrInvalidProbability
+	}
+	if err := checkRegression(y, x); err != nil {
+		return nil, err
+	}
+	coef, err := leastSquares(y, x, nil)
+	if err != nil {
+		return nil, err
+	}
+	var scale float64
+	for i := 0; i < len(y); i++ {
+		scale = math.Max(scale, math.Abs(y[i]))
+	}
+	eps := 1e-8 * math.Max(scale, 1)
+	w := make([]float64, len(y))
+	for iter := 0; iter < quantileMaxIter; iter++ {
+		for i := 0; i < len(y); i++ {
+			r := y[i] - predict(coef, x, i)
+			w[i] = p
+			if r < 0 {
+				w[i] = 1 - p
+			}
+			w[i] /= math.Max(math.Abs(r), eps)
+		}
+		next, err := leastSquares(y, x, w)
+		if err != nil {
+			return nil, err
+		}
+		var delta float64
+		for j := 0; j < len(coef); j++ {
+			delta = math.Max(delta, math.Abs(next[j]-coef[j])/(1+math.Abs(coef[j])))
+		}
+		coef = next
+		if delta < quantileTol {
+			return coef, nil
+		}
+	}
+	return coef, ErrNotConverged
+}
+
+// checkRegression returns an error unless y and the predictor series x are
+// aligned and have more observations than coefficients to fit.
+func checkRegression(y []float64, x [][]float64) error {
+	for j := 0; j < len(x); j++ {
+		if len(x[j]) != len(y) {
+			return ErrLengthMismatch
+		}
+	}
+	return checkInput(y, len(x)+1)
+}
+
+// leastSquares returns the coefficients of the least squares regression of y
+// on the aligned series x, the intercept first, with observations weighted
+// by w, or equally when w is nil.
+func leastSquares(y []float64, x [][]float64, w []float64) ([]float64, error) {
+	p := len(x) + 1
+	a := mat64.NewSymDense(p, nil)
+	b := mat64.NewVector(p, nil)
+	row := make([]float64, p)
+	row[0] = 1
+	for i := 0; i < len(y); i++ {
+		wi := 1.0
+		if w != nil {
+			wi = w[i]
+		}
+		for j := 0; j < len(x); j++ {
+			row[j+1] = x[j][i]
+		}
+		for r := 0; r < p; r++ {
+			b.SetVec(r, b.At(r, 0)+wi*row[r]*y[i])
+			for c := r; c < p; c++ {
+				a.SetSym(r, c, a.At(r, c)+wi*row[r]*row[c])
+			}
+		}
+	}
+	var chol mat64.Cholesky
+	if !chol.Factorize(a) {
+		return nil, ErrSingularMatrix
+	}
+	var coef mat64.Vector
+	if err := coef.SolveCholeskyVec(&chol, b); err != nil {
+		return nil, ErrSingularMatrix
+	}
+	rets := make([]float64, p)
+	for j := 0; j < p; j++ {
+		rets[j] = coef.At(j, 0)
+	}
+	return rets, nil
+}
+
+// predict returns the value fitted by the regression coefficients coef for
+// the i-th observation of the predictor series x.
+func predict(coef []float64, x [][]float64, i int) float64 {
+	v := coef[0]
+	for j := 0; j < len(x); j++ {
+		v += coef[j+1] * x[j][i]
+	}
+	return v
+}
+
+// IsotonicRegression returns the monotone fit of y on the covariate x, the
+// values closest to y in weighted least squares which are non-decreasing in
+// x, or non-increasing when increasing is false, for example to calibrate
+// scores into probabilities. The fitted values are aligned with y, and
+// observations with equal covariate values share a fitted value. When x is
+// nil the observations are taken in the order of y. The fit is found by the
+// pool adjacent violators algorithm with observations weighted by weights,
+// or equally when weights is nil.
+func IsotonicRegression(x, y, weights []float64, increasing bool) []float64 {
+	if x != nil {
+		checkSeries([][]float64{x, y})
+	}
+	if weights != nil {
+		checkSeries([][]float64{weights, y})
+	}
+	idx := make([]int, len(y))
+	for i := 0; i < len(idx); i++ {
+		idx[i] = i
+	}
+	if x != nil {
+		sort.Stable(byValue{idx, x})
+	}
+	sign := 1.0
+	if !increasing {
+		sign = -1
+	}
+
+	// each block pools a run of observations fitted by their weighted mean
+	type block struct {
+		start, end int
+		sum, w     float64
+	}
+	blocks := make([]block, 0, len(y))
+	for k := 0; k < len(idx); k++ {
+		i := idx[k]
+		w := 1.0
+		if weights != nil {
+			w = weights[i]
+		}
+		b := block{start: k, end: k + 1, sum: w * sign * y[i], w: w}
+		if n := len(blocks); n > 0 && x != nil && x[idx[blocks[n-1].end-1]] == x[i] {
+			blocks[n-1].end, blocks[n-1].sum, blocks[n-1].w = b.end, blocks[n-1].sum+b.sum, blocks[n-1].w+b.w
+		} else {
+			blocks = append(blocks, b)
+		}
+		for n := len(blocks); n > 1 && blocks[n-2].sum*blocks[n-1].w >= blocks[n-1].sum*blocks[n-2].w; n-- {
+			last := blocks[n-1]
+			blocks[n-2].end, blocks[n-2].sum, blocks[n-2].w = last.end, blocks[n-2].sum+last.sum, blocks[n-2].w+last.w
+			blocks = blocks[:n-1]
+		}
+	}
+
+	fit := make([]float64, len(y))
+	for _, b := range blocks {
+		for k := b.start; k < b.end; k++ {
+			fit[idx[k]] = sign * b.sum / b.w
+		}
+	}
+	return fit
+}
@@ -0,0 +1,65 @@
+package gostat
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestQuantileRegression(t *testing.T) {
+	x := []float64{1., 2., 3., 4., 5., 6., 7., 8., 9.}
+	y := []float64{3., 5., 7., 9., 100., 13., 15., 17., 19.}
+	coef, err := QuantileRegression(y, [][]float64{x}, 0.5)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	compareArrays([]float64{1., 2.}, coef, t)
+}
+
+func TestQuantileRegression_Quantiles(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	n := 2000
+	x := make([]float64, n)
+	y := make([]float64, n)
+	for i := 0; i < n; i++ {
+		x[i] = rnd.Float64() * 10
+		// the noise grows with x, so that the quantiles fan out
+		y[i] = 1 + 2*x[i] + x[i]*rnd.NormFloat64()
+	}
+	for _, c := range []struct{ p, slope float64 }{{0.1, 0.7184}, {0.5, 2.}, {0.9, 3.2816}} {
+		coef, err := QuantileRegression(y, [][]float64{x}, c.p)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if got := coef[1]; got < c.slope-0.15 || got > c.slope+0.15 {
+			t.Errorf("Expected slope of %.1f quantile near %f, got=%f", c.p, c.slope, got)
+		}
+	}
+}
+
+func TestQuantileRegression_Errors(t *testing.T) {
+	x := [][]float64{{1., 2., 3.}}
+	if _, err := QuantileRegression([]float64{1., 2., 3.}, x, 1.); err != ErrInvalidProbability {
+		t.Errorf("Expected error=%v, got=%v", ErrInvalidProbability, err)
+	}
+	if _, err := QuantileRegression([]float64{1., 2.}, x, 0.5); err != ErrLengthMismatch {
+		t.Errorf("Expected error=%v, got=%v", ErrLengthMismatch, err)
+	}
+	if _, err := QuantileRegression([]float64{1., 2., 3.}, [][]float64{{1., 1., 1.}}, 0.5); err != ErrSingularMatrix {
+		t.Errorf("Expected error=%v, got=%v", ErrSingularMatrix, err)
+	}
+}
+
+func TestIsotonicRegression(t *testing.T) {
+	y := []float64{1., 3., 2., 4., 3.5, 5.}
+	compareArrays([]float64{1., 2.5, 2.5, 3.75, 3.75, 5.}, IsotonicRegression(nil, y, nil, true), t)
+	compareArrays([]float64{3.0833, 3.0833, 3.0833, 3.0833, 3.0833, 3.0833}, IsotonicRegression(nil, y, nil, false), t)
+	compareArrays([]float64{5., 3., 3., 1.}, IsotonicRegression(nil, []float64{5., 2., 4., 1.}, nil, false), t)
+}
+
+func TestIsotonicRegression_Covariate(t *testing.T) {
+	x := []float64{3., 1., 2., 2., 4.}
+	y := []float64{2., 1., 4., 2., 5.}
+	w := []float64{1., 1., 1., 1., 2.}
+	compareArrays([]float64{2.6667, 1., 2.6667, 2.6667, 5.}, IsotonicRegression(x, y, w, true), t)
+	compareArrays([]float64{3.1667, 3.1667, 3.1667, 3.1667, 3.1667}, IsotonicRegression(x, y, w, false), t)
+}
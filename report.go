@@ -0,0 +1,181 @@
+package gostat
+
+// OutlierMethod is an outlier detection method run by ReportOutliers.
+type OutlierMethod int
+
+const (
+	// OutlierTukey flags the values outside of the Tukey fences, see
+	// OutliersIQR.
+	OutlierTukey OutlierMethod = iota
+	// OutlierMAD flags the values with a large modified z-score, see
+	// OutliersMAD.
+	OutlierMAD
+	// OutlierGrubbs flags the values rejected by the iterated Grubbs test,
+	// see OutliersGrubbs.
+	OutlierGrubbs
+	// OutlierHampel flags the values deviating from their rolling median,
+	// see Hampel.
+	OutlierHampel
+)
+
+var outlierMethodNames = []string{"tukey", "mad", "grubbs", "hampel"}
+
+// String returns the name of the method, such as "mad".
+func (m OutlierMethod) String() string {
+	if m < 0 || int(m) >= len(outlierMethodNames) {
+		return "unknown"
+	}
+	return outlierMethodNames[m]
+}
+
+// MarshalText encodes the method by its name, so that a report serialized
+// to JSON names the methods.
+func (m OutlierMethod) MarshalText() ([]byte, error) {
+	if m < 0 || int(m) >= len(outlierMethodNames) {
+		return nil, ErrInvalidParameter
+	}
+	return []byte(m.String()), nil
+}
+
+// UnmarshalText decodes a method encoded by MarshalText.
+func (m *OutlierMethod) UnmarshalText(text []byte) error {
+	for i := 0; i < len(outlierMethodNames); i++ {
+		if outlierMethodNames[i] == string(text) {
+			*m = OutlierMethod(i)
+			return nil
+		}
+	}
+	return ErrInvalidParameter
+}
+
+// ReportOpts configures the battery of methods run by ReportOutliers. The
+// parameters left at zero take the usual values given in brackets.
+type ReportOpts struct {
+	// Methods are the methods to run, all of them when nil.
+	Methods []OutlierMethod
+	// TukeyK is the number of interquartile ranges of the Tukey fences
+	// [1.5].
+	TukeyK float64
+	// MADThreshold is the modified z-score threshold [3.5].
+	MADThreshold float64
+	// GrubbsAlpha is the significance level of the Grubbs test [0.05].
+	GrubbsAlpha float64
+	// HampelWindow is the length of the windows of the Hampel identifier
+	// [7], and HampelThreshold its threshold [3].
+	HampelWindow    int
+	HampelThreshold float64
+	// MinVotes is the number of methods which must flag a value for it to
+	// be a consensus outlier [a majority of the methods].
+	MinVotes int
+}
+
+// MethodOutliers are the outliers flagged by a method.
+type MethodOutliers struct {
+	Method OutlierMethod
+	// Indices are the indices of the flagged values in ascending order.
+	Indices []int
+}
+
+// OutlierReport is the consolidated result of a battery of outlier
+// detection methods, for audit workflows such as the review of charges
+// described by MAD. It holds no NaN values, so that it can be serialized
+// with encoding/json.
+type OutlierReport struct {
+	// Opts are the options used, with the defaults filled in.
+	Opts ReportOpts
+	// Count is the number of values, Mean and StdDev their mean and
+	// unbiased standard deviation, Median and MAD their median and median
+	// absolute deviation, and Min and Max the lowest and highest value.
+	Count        int
+	Mean, StdDev float64
+	Median, MAD  float64
+	Min, Max     float64
+	// Methods are the outliers flagged by each method, in the order of
+	// Opts.Methods.
+	Methods []MethodOutliers
+	// Votes holds the number of methods which flagged each value.
+	Votes []int
+	// Consensus are the indices of the values flagged by at least
+	// Opts.MinVotes methods, in ascending order.
+	Consensus []int
+}
+
+// ReportOutliers runs the outlier detection methods selected by opts over x
+// and consolidates their flags in a report. ErrEmptyInput is returned when
+// x has fewer than three values, ErrNaNInput when it contains NaN or
+// infinite values, and ErrInvalidParameter for an unknown method.
+func ReportOutliers(x []float64, opts ReportOpts) (OutlierReport, error) {
+	if len(x) < 3 {
+		return OutlierReport{}, ErrEmptyInput
+	}
+	for i := 0; i < len(x); i++ {
+		if !isRealVal(x[i]) {
+			return OutlierReport{}, ErrNaNInput
+		}
+	}
+	opts = opts.withDefaults()
+	s := Describe(x, nil)
+	r := OutlierReport{
+		Opts:      opts,
+		Count:     s.Count,
+		Mean:      s.Mean,
+		StdDev:    s.StdDev,
+		Median:    s.Median,
+		MAD:       s.MAD,
+		Min:       s.Min,
+		Max:       s.Max,
+		Methods:   make([]MethodOutliers, len(opts.Methods)),
+		Votes:     make([]int, len(x)),
+		Consensus: []int{},
+	}
+	for j, m := range opts.Methods {
+		var idx []int
+		switch m {
+		case OutlierTukey:
+			idx = OutliersIQR(x, opts.TukeyK)
+		case OutlierMAD:
+			idx = OutliersMAD(x, opts.MADThreshold)
+		case OutlierGrubbs:
+			idx = OutliersGrubbs(x, opts.GrubbsAlpha)
+		case OutlierHampel:
+			idx = Hampel(x, opts.HampelWindow, opts.HampelThreshold)
+		default:
+			return OutlierReport{}, ErrInvalidParameter
+		}
+		r.Methods[j] = MethodOutliers{Method: m, Indices: append([]int{}, idx...)}
+		for _, i := range idx {
+			r.Votes[i]++
+		}
+	}
+	for i := 0; i < len(x); i++ {
+		if r.Votes[i] >= opts.MinVotes {
+			r.Consensus = append(r.Consensus, i)
+		}
+	}
+	return r, nil
+}
+
+func (opts ReportOpts) withDefaults() ReportOpts {
+	if opts.Methods == nil {
+		opts.Methods = []OutlierMethod{OutlierTukey, OutlierMAD, OutlierGrubbs, OutlierHampel}
+	}
+	if opts.TukeyK == 0 {
+		opts.TukeyK = 1.5
+	}
+	if opts.MADThreshold == 0 {
+		opts.MADThreshold = 3.5
+	}
+	if opts.GrubbsAlpha == 0 {
+		opts.GrubbsAlpha = 0.05
+	}
+	if opts.HampelWindow == 0 {
+		opts.HampelWindow = 7
+	}
+	if opts.HampelThreshold == 0 {
+		opts.HampelThreshold = 3
+	}
+	if opts.MinVotes == 0 {
+		opts.MinVotes = len(opts.Methods)/2 + 1
+	}
+	return opts
+}
@@ -0,0 +1,67 @@
+package gostat
+
+import (
+	"encoding/json"
+	"math"
+	"testing"
+)
+
+func TestReportOutliers(t *testing.T) {
+	x := []float64{2.1, 2.3, 1.9, 2.0, 2.2, 2.1, 1.8, 2.0, 9.5, 2.2, 2.05, 1.95}
+	r, err := ReportOutliers(x, ReportOpts{})
+	if err != nil {
+		t.Fatalf("Expected no error, got=%v", err)
+	}
+	if got, want := r.Opts.MinVotes, 3; got != want {
+		t.Errorf("Expected MinVotes=%d, got=%d", want, got)
+	}
+	if got, want := len(r.Methods), 4; got != want {
+		t.Fatalf("Expected %d methods, got=%d", want, got)
+	}
+	for _, m := range r.Methods {
+		compareIndices([]int{8}, m.Indices, t)
+	}
+	if got, want := r.Votes[8], 4; got != want {
+		t.Errorf("Expected votes=%d, got=%d", want, got)
+	}
+	compareIndices([]int{8}, r.Consensus, t)
+	if got, want := r.Median, 2.075; !floatEquals(got, want) {
+		t.Errorf("Expected Median=%f, got=%f", want, got)
+	}
+
+	data, err := json.Marshal(r)
+	if err != nil {
+		t.Fatalf("Expected no error, got=%v", err)
+	}
+	var decoded OutlierReport
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("Expected no error, got=%v", err)
+	}
+	if got, want := decoded.Methods[2].Method, OutlierGrubbs; got != want {
+		t.Errorf("Expected method=%v, got=%v", want, got)
+	}
+	compareIndices(r.Consensus, decoded.Consensus, t)
+}
+
+func TestReportOutliers_Options(t *testing.T) {
+	x := []float64{1., 2., 3., 4., 5., 6., 7., 8., 9., 30.}
+	r, err := ReportOutliers(x, ReportOpts{Methods: []OutlierMethod{OutlierTukey, OutlierMAD}, TukeyK: 5, MinVotes: 1})
+	if err != nil {
+		t.Fatalf("Expected no error, got=%v", err)
+	}
+	compareIndices(nil, r.Methods[0].Indices, t)
+	compareIndices([]int{9}, r.Methods[1].Indices, t)
+	compareIndices([]int{9}, r.Consensus, t)
+}
+
+func TestReportOutliers_Invalid(t *testing.T) {
+	if _, err := ReportOutliers([]float64{1., 2.}, ReportOpts{}); err != ErrEmptyInput {
+		t.Errorf("Expected err=%v, got=%v", ErrEmptyInput, err)
+	}
+	if _, err := ReportOutliers([]float64{1., 2., math.NaN()}, ReportOpts{}); err != ErrNaNInput {
+		t.Errorf("Expected err=%v, got=%v", ErrNaNInput, err)
+	}
+	if _, err := ReportOutliers([]float64{1., 2., 3.}, ReportOpts{Methods: []OutlierMethod{7}}); err != ErrInvalidParameter {
+		t.Errorf("Expected err=%v, got=%v", ErrInvalidParameter, err)
+	}
+}
@@ -0,0 +1,90 @@
+package gostat
+
+import (
+	"math"
+	"time"
+)
+
+// LogReturns returns the logarithmic returns of prices, ln(p[i]/p[i-1]),
+// one fewer than there are prices.
+func LogReturns(prices []float64) []float64 {
+	return LogReturnsN(prices, 1)
+}
+
+// LogReturnsN returns the n-period logarithmic returns of prices,
+// ln(p[i]/p[i-n]), n fewer than there are prices.
+func LogReturnsN(prices []float64, n int) []float64 {
+	return returnsN(prices, n, func(from, to float64) float64 {
+		return math.Log(to / from)
+	})
+}
+
+// SimpleReturns returns the simple returns of prices, p[i]/p[i-1] - 1, one
+// fewer than there are prices.
+func SimpleReturns(prices []float64) []float64 {
+	return SimpleReturnsN(prices, 1)
+}
+
+// SimpleReturnsN returns the n-period simple returns of prices,
+// p[i]/p[i-n] - 1, n fewer than there are prices.
+func SimpleReturnsN(prices []float64, n int) []float64 {
+	return returnsN(prices, n, func(from, to float64) float64 {
+		return to/from - 1
+	})
+}
+
+// CumulativeReturns returns the compounded growth of a series of simple
+// returns, where the i-th value is the total return up to and including the
+// i-th period.
+func CumulativeReturns(returns []float64) []float64 {
+	cum := make([]float64, len(returns))
+	growth := 1.
+	for i := 0; i < len(returns); i++ {
+		growth *= 1 + returns[i]
+		cum[i] = growth - 1
+	}
+	return cum
+}
+
+// LogReturnsTime returns the logarithmic returns of prices observed at the
+// given times. When period is positive each return is scaled by the square
+// root of period divided by the time elapsed since the previous price, so
+// that a return spanning a weekend or a holiday has the same variance as a
+// return over a single period. A period of 24 hours, for example, scales a
+// Friday to Monday return of daily prices by 1/sqrt(3).
+func LogReturnsTime(prices []float64, times []time.Time, period time.Duration) ([]float64, error) {
+	return scaleReturns(LogReturns(prices), prices, times, period)
+}
+
+// SimpleReturnsTime returns the simple returns of prices observed at the
+// given times, scaled as described by LogReturnsTime.
+func SimpleReturnsTime(prices []float64, times []time.Time, period time.Duration) ([]float64, error) {
+	return scaleReturns(SimpleReturns(prices), prices, times, period)
+}
+
+func returnsN(prices []float64, n int, ret func(from, to float64) float64) []float64 {
+	if n < 1 || len(prices) <= n {
+		return []float64{}
+	}
+	rets := make([]float64, len(prices)-n)
+	for i := n; i < len(prices); i++ {
+		rets[i-n] = ret(prices[i-n], prices[i])
+	}
+	return rets
+}
+
+func scaleReturns(rets, prices []float64, times []time.Time, period time.Duration) ([]float64, error) {
+	if len(times) != len(prices) {
+		return nil, ErrLengthMismatch
+	}
+	for i := 1; i < len(times); i++ {
+		elapsed := times[i].Sub(times[i-1])
+		if elapsed <= 0 {
+			return nil, ErrInvalidParameter
+		}
+		if period > 0 {
+			rets[i-1] *= math.Sqrt(float64(period) / float64(elapsed))
+		}
+	}
+	return rets, nil
+}
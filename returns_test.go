@@ -0,0 +1,53 @@
+package gostat
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestLogReturns(t *testing.T) {
+	prices := []float64{100., 110., 99.}
+	compareArrays([]float64{math.Log(1.1), math.Log(0.9)}, LogReturns(prices), t)
+	compareArrays([]float64{math.Log(0.99)}, LogReturnsN(prices, 2), t)
+	compareArrays([]float64{}, LogReturns([]float64{100.}), t)
+}
+
+func TestSimpleReturns(t *testing.T) {
+	prices := []float64{100., 110., 99.}
+	compareArrays([]float64{0.1, -0.1}, SimpleReturns(prices), t)
+	compareArrays([]float64{-0.01}, SimpleReturnsN(prices, 2), t)
+	compareArrays([]float64{}, SimpleReturnsN(prices, 0), t)
+}
+
+func TestCumulativeReturns(t *testing.T) {
+	compareArrays([]float64{0.1, -0.01, 0.089}, CumulativeReturns([]float64{0.1, -0.1, 0.1}), t)
+}
+
+func TestLogReturnsTime(t *testing.T) {
+	friday := time.Date(2017, 3, 3, 16, 0, 0, 0, time.UTC)
+	times := []time.Time{friday.AddDate(0, 0, -1), friday, friday.AddDate(0, 0, 3)}
+	prices := []float64{100., 101., 102.}
+
+	rets, err := LogReturnsTime(prices, times, 24*time.Hour)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	compareArrays([]float64{math.Log(1.01), math.Log(102./101.) / math.Sqrt(3.)}, rets, t)
+
+	rets, err = SimpleReturnsTime(prices, times, 0)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	compareArrays([]float64{0.01, 1. / 101.}, rets, t)
+}
+
+func TestLogReturnsTime_Errors(t *testing.T) {
+	now := time.Now()
+	if _, err := LogReturnsTime([]float64{1., 2.}, []time.Time{now}, time.Hour); err != ErrLengthMismatch {
+		t.Errorf("Expected error=%v, got=%v", ErrLengthMismatch, err)
+	}
+	if _, err := LogReturnsTime([]float64{1., 2.}, []time.Time{now, now}, time.Hour); err != ErrInvalidParameter {
+		t.Errorf("Expected error=%v, got=%v", ErrInvalidParameter, err)
+	}
+}
@@ -0,0 +1,73 @@
+package gostat
+
+import (
+	"math"
+)
+
+// Semivariance returns the average squared deviation of x from threshold,
+// counting only the elements below the threshold when below is true, or
+// the elements above it otherwise. The sum of squared deviations is divided
+// by the total number of elements, so that the downside and the upside
+// semivariance around the mean add up to the population variance.
+func Semivariance(x []float64, threshold float64, below bool) float64 {
+	if len(x) == 0 {
+		return math.NaN()
+	}
+	var sum neumaierSum
+	for i := 0; i < len(x); i++ {
+		d := x[i] - threshold
+		if (below && d < 0) || (!below && d > 0) {
+			sum.add(d * d)
+		}
+	}
+	return sum.total() / float64(len(x))
+}
+
+// Semideviation returns the square root of the semivariance of x around
+// threshold.
+func Semideviation(x []float64, threshold float64, below bool) float64 {
+	return math.Sqrt(Semivariance(x, threshold, below))
+}
+
+// AnnualizedSemideviation returns the semideviation of returns x around
+// threshold scaled by the square root of periodicity, the number of
+// periods per year, following the same convention as Volatility.
+func AnnualizedSemideviation(x []float64, threshold float64, below bool, periodicity float64) float64 {
+	return Semideviation(x, threshold, below) * math.Sqrt(periodicity)
+}
+
+// DownsideDeviation returns the annualized downside deviation of returns x
+// below target, the minimum acceptable return per period, scaled by the
+// square root of periodicity like Volatility.
+func DownsideDeviation(x []float64, target, periodicity float64) float64 {
+	return AnnualizedSemideviation(x, target, true, periodicity)
+}
+
+// SharpeRatio returns the annualized Sharpe ratio of returns x, the mean
+// excess return over riskFree, the risk-free rate per period, divided by the
+// standard deviation of the excess returns and scaled by the square root of
+// periodicity, the number of periods per year. NaN is returned for fewer
+// than two returns.
+func SharpeRatio(x []float64, riskFree, periodicity float64) float64 {
+	if len(x) < 2 {
+		return math.NaN()
+	}
+	excess := make([]float64, len(x))
+	for i := 0; i < len(x); i++ {
+		excess[i] = x[i] - riskFree
+	}
+	mean, std := Mean(excess, nil), StdDev(excess, nil, VarianceTwoPass)
+	return mean / std * math.Sqrt(periodicity)
+}
+
+// SortinoRatio returns the annualized Sortino ratio of returns x, the mean
+// excess return over target, the minimum acceptable return per period,
+// divided by the downside deviation below target and scaled by the square
+// root of periodicity. NaN is returned for an empty slice.
+func SortinoRatio(x []float64, target, periodicity float64) float64 {
+	if len(x) == 0 {
+		return math.NaN()
+	}
+	mean := Mean(x, nil) - target
+	return mean / Semideviation(x, target, true) * math.Sqrt(periodicity)
+}
@@ -0,0 +1,57 @@
+package gostat
+
+import (
+	"github.com/gonum/floats"
+	"math"
+	"testing"
+)
+
+func TestSemivariance(t *testing.T) {
+	x := []float64{0.02, -0.01, 0.03, -0.04, 0.01}
+	// the semivariances are far below the absolute tolerance of floatEquals
+	if got, want := Semivariance(x, 0., true), 0.00034; !floats.EqualWithinRel(got, want, 1e-9) {
+		t.Errorf("Expected semivariance=%g, got=%g", want, got)
+	}
+	if got, want := Semivariance(x, 0., false), 0.00028; !floats.EqualWithinRel(got, want, 1e-9) {
+		t.Errorf("Expected semivariance=%g, got=%g", want, got)
+	}
+}
+
+func TestSemivariance_Empty(t *testing.T) {
+	if got := Semivariance(nil, 0., true); !math.IsNaN(got) {
+		t.Errorf("Expected semivariance=NaN, got=%f", got)
+	}
+}
+
+func TestAnnualizedSemideviation(t *testing.T) {
+	x := []float64{0.02, -0.01, 0.03, -0.04, 0.01}
+	if got, want := Semideviation(x, 0., true), 0.018439; !floatEquals(got, want) {
+		t.Errorf("Expected semideviation=%f, got=%f", want, got)
+	}
+	if got, want := AnnualizedSemideviation(x, 0., true, 252.), 0.2927; !floatEquals(got, want) {
+		t.Errorf("Expected semideviation=%f, got=%f", want, got)
+	}
+}
+
+func TestSharpeRatio(t *testing.T) {
+	x := []float64{0.01, 0.02, -0.01, 0.03, -0.02}
+	if got, want := SharpeRatio(x, 0., 252.), 4.5932; !floatEquals(got, want) {
+		t.Errorf("Expected Sharpe ratio=%f, got=%f", want, got)
+	}
+	if got, want := SharpeRatio(x, 0.001, 252.), 3.8277; !floatEquals(got, want) {
+		t.Errorf("Expected Sharpe ratio=%f, got=%f", want, got)
+	}
+	if got := SharpeRatio([]float64{0.01}, 0., 252.); !math.IsNaN(got) {
+		t.Errorf("Expected Sharpe ratio=NaN, got=%f", got)
+	}
+}
+
+func TestSortinoRatio(t *testing.T) {
+	x := []float64{0.01, 0.02, -0.01, 0.03, -0.02}
+	if got, want := SortinoRatio(x, 0., 252.), 9.5247; !floatEquals(got, want) {
+		t.Errorf("Expected Sortino ratio=%f, got=%f", want, got)
+	}
+	if got, want := DownsideDeviation(x, 0., 252.), 0.158745; !floatEquals(got, want) {
+		t.Errorf("Expected downside deviation=%f, got=%f", want, got)
+	}
+}
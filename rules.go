@@ -0,0 +1,150 @@
+package gostat
+
+import (
+	"math"
+)
+
+// ControlRule is a run rule detecting non-random patterns in a control
+// chart.
+type ControlRule int
+
+const (
+	// NelsonBeyond3Sigma flags a point more than 3 sigma from the center.
+	NelsonBeyond3Sigma ControlRule = iota + 1
+	// NelsonSameSide flags 9 points in a row on the same side of the
+	// center.
+	NelsonSameSide
+	// NelsonTrend flags 6 points in a row steadily increasing or
+	// decreasing.
+	NelsonTrend
+	// NelsonAlternating flags 14 points in a row alternating up and down.
+	NelsonAlternating
+	// NelsonTwoOfThree flags 2 out of 3 points in a row more than 2 sigma
+	// from the center on the same side.
+	NelsonTwoOfThree
+	// NelsonFourOfFive flags 4 out of 5 points in a row more than 1 sigma
+	// from the center on the same side.
+	NelsonFourOfFive
+	// NelsonWithin1Sigma flags 15 points in a row within 1 sigma of the
+	// center.
+	NelsonWithin1Sigma
+	// NelsonOutside1Sigma flags 8 points in a row more than 1 sigma from
+	// the center on either side.
+	NelsonOutside1Sigma
+	// WesternElectricSameSide flags 8 points in a row on the same side of
+	// the center, the Western Electric variant of NelsonSameSide.
+	WesternElectricSameSide
+)
+
+// RuleViolation lists the points at which a control rule fired, given as
+// the indices of the last point of each flagged pattern.
+type RuleViolation struct {
+	Rule    ControlRule
+	Indices []int
+}
+
+// ControlRules evaluates the control rules over the values x of a control
+// chart with given center line and sigma, such as the Center of a
+// ControlChart and the SigmaWithin of its process, and returns the rules
+// that fired in the order they were given, or all the Nelson rules when
+// none are given.
+func ControlRules(x []float64, center, sigma float64, rules ...ControlRule) []RuleViolation {
+	if len(rules) == 0 {
+		rules = []ControlRule{
+			NelsonBeyond3Sigma, NelsonSameSide, NelsonTrend, NelsonAlternating,
+			NelsonTwoOfThree, NelsonFourOfFive, NelsonWithin1Sigma, NelsonOutside1Sigma,
+		}
+	}
+	z := make([]float64, len(x))
+	for i := 0; i < len(x); i++ {
+		z[i] = (x[i] - center) / sigma
+	}
+	var violations []RuleViolation
+	for _, rule := range rules {
+		var idx []int
+		for i := 0; i < len(z); i++ {
+			if ruleFires(rule, z, i) {
+				idx = append(idx, i)
+			}
+		}
+		if len(idx) > 0 {
+			violations = append(violations, RuleViolation{Rule: rule, Indices: idx})
+		}
+	}
+	return violations
+}
+
+// ruleFires reports whether rule fires for the pattern of standardized
+// values z ending at index i.
+func ruleFires(rule ControlRule, z []float64, i int) bool {
+	switch rule {
+	case NelsonBeyond3Sigma:
+		return math.Abs(z[i]) > 3
+	case NelsonSameSide:
+		return sameSide(z, i, 9)
+	case WesternElectricSameSide:
+		return sameSide(z, i, 8)
+	case NelsonTrend:
+		return i >= 5 && (monotonic(z[i-5:i+1], 1) || monotonic(z[i-5:i+1], -1))
+	case NelsonAlternating:
+		if i < 13 {
+			return false
+		}
+		for j := i - 11; j <= i; j++ {
+			if (z[j]-z[j-1])*(z[j-1]-z[j-2]) >= 0 {
+				return false
+			}
+		}
+		return true
+	case NelsonTwoOfThree:
+		return countBeyond(z, i, 3, 2, 1) >= 2 || countBeyond(z, i, 3, 2, -1) >= 2
+	case NelsonFourOfFive:
+		return countBeyond(z, i, 5, 1, 1) >= 4 || countBeyond(z, i, 5, 1, -1) >= 4
+	case NelsonWithin1Sigma:
+		return i >= 14 && allOf(z[i-14:i+1], func(v float64) bool { return math.Abs(v) < 1 })
+	case NelsonOutside1Sigma:
+		return i >= 7 && allOf(z[i-7:i+1], func(v float64) bool { return math.Abs(v) > 1 })
+	}
+	return false
+}
+
+func sameSide(z []float64, i, n int) bool {
+	if i < n-1 {
+		return false
+	}
+	return allOf(z[i-n+1:i+1], func(v float64) bool { return v > 0 }) ||
+		allOf(z[i-n+1:i+1], func(v float64) bool { return v < 0 })
+}
+
+func monotonic(z []float64, sign float64) bool {
+	for j := 1; j < len(z); j++ {
+		if sign*(z[j]-z[j-1]) <= 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// countBeyond returns the number of the n values ending at index i which
+// are beyond limit on the side of sign, or 0 if there are fewer values.
+func countBeyond(z []float64, i, n int, limit, sign float64) int {
+	if i < n-1 {
+		return 0
+	}
+	var count int
+	for j := i - n + 1; j <= i; j++ {
+		if sign*z[j] > limit {
+			count++
+		}
+	}
+	return count
+}
+
+func allOf(z []float64, pred func(float64) bool) bool {
+	for i := 0; i < len(z); i++ {
+		if !pred(z[i]) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,40 @@
+package gostat
+
+import (
+	"testing"
+)
+
+func TestControlRules(t *testing.T) {
+	cases := []struct {
+		rule ControlRule
+		z    []float64
+		want []int
+	}{
+		{NelsonBeyond3Sigma, []float64{0., 3.5, -1., -3.2}, []int{1, 3}},
+		{NelsonSameSide, []float64{-1., .1, .2, .3, .1, .5, .2, .3, .4, .1, .2, -1.}, []int{9, 10}},
+		{WesternElectricSameSide, []float64{.1, .2, .3, .1, .5, .2, .3, .4, -1.}, []int{7}},
+		{NelsonTrend, []float64{0., -1., -.5, 0., .2, .4, .6, .5}, []int{6}},
+		{NelsonAlternating, []float64{0., 1., 0., 1., 0., 1., 0., 1., 0., 1., 0., 1., 0., 1., 1.}, []int{13}},
+		{NelsonTwoOfThree, []float64{0., 2.5, 0., 2.1, -2.5, 1.2}, []int{3}},
+		{NelsonFourOfFive, []float64{1.5, 1.2, 0., 1.1, 1.3, -.5}, []int{4}},
+		{NelsonWithin1Sigma, []float64{.1, -.2, .3, -.1, .5, -.2, .3, -.4, .1, -.2, .2, -.3, .1, -.5, .2, 2.}, []int{14}},
+		{NelsonOutside1Sigma, []float64{1.5, -1.2, 1.1, -1.3, 1.4, -2., 1.2, -1.1, 0.}, []int{7}},
+	}
+	for _, c := range cases {
+		v := ControlRules(c.z, 0., 1., c.rule)
+		if len(v) != 1 || v[0].Rule != c.rule {
+			t.Errorf("Expected rule %d to fire, got=%v", c.rule, v)
+			continue
+		}
+		compareIndices(c.want, v[0].Indices, t)
+	}
+}
+
+func TestControlRules_Default(t *testing.T) {
+	x := []float64{10., 10.1, 9.9, 10., 13.5, 10.1, 9.8}
+	v := ControlRules(x, 10., 1.)
+	if len(v) != 1 || v[0].Rule != NelsonBeyond3Sigma {
+		t.Fatalf("Expected only rule %d to fire, got=%v", NelsonBeyond3Sigma, v)
+	}
+	compareIndices([]int{4}, v[0].Indices, t)
+}
@@ -0,0 +1,80 @@
+package gostat
+
+import (
+	"math"
+	"time"
+)
+
+// Profile is an intraday profile, a statistic of values observed in each
+// bucket of the time of day, returned by IntradayProfile.
+type Profile struct {
+	// Bucket is the length of the buckets, the first of which starts at
+	// midnight.
+	Bucket time.Duration
+	// Values holds the statistic of each bucket, NaN for buckets without
+	// any observation.
+	Values []float64
+}
+
+// IntradayProfile returns the intraday profile of values observed at times,
+// the statistic statFn of the values in each bucket of the time of day, such
+// as the median volatility per 30 minute bucket. The time of day is taken in
+// the location of each time. NaN values are ignored. ErrLengthMismatch is
+// returned when values and times have different lengths, and
+// ErrInvalidParameter for a bucket length that is not positive or longer
+// than a day.
+func IntradayProfile(values []float64, times []time.Time, bucket time.Duration, statFn func([]float64) float64) (Profile, error) {
+	if len(values) != len(times) {
+		return Profile{}, ErrLengthMismatch
+	}
+	if bucket <= 0 || bucket > 24*time.Hour {
+		return Profile{}, ErrInvalidParameter
+	}
+	n := int((24*time.Hour + bucket - 1) / bucket)
+	groups := make([][]float64, n)
+	p := Profile{Bucket: bucket, Values: make([]float64, n)}
+	for i := 0; i < len(values); i++ {
+		if math.IsNaN(values[i]) {
+			continue
+		}
+		b := p.bucket(times[i])
+		groups[b] = append(groups[b], values[i])
+	}
+	for b := 0; b < n; b++ {
+		if len(groups[b]) == 0 {
+			p.Values[b] = math.NaN()
+			continue
+		}
+		p.Values[b] = statFn(groups[b])
+	}
+	return p, nil
+}
+
+// At returns the value of the profile in the bucket of the time of day of t.
+func (p Profile) At(t time.Time) float64 {
+	return p.Values[p.bucket(t)]
+}
+
+// Deseasonalize returns values observed at times divided by the value of the
+// profile at each time, removing the intraday seasonality from a
+// multiplicative quantity such as volatility or volume. ErrLengthMismatch
+// is returned when values and times have different lengths.
+func (p Profile) Deseasonalize(values []float64, times []time.Time) ([]float64, error) {
+	if len(values) != len(times) {
+		return nil, ErrLengthMismatch
+	}
+	v := make([]float64, len(values))
+	for i := 0; i < len(values); i++ {
+		v[i] = values[i] / p.At(times[i])
+	}
+	return v, nil
+}
+
+// bucket returns the index of the bucket of the time of day of t, read from
+// its clock so that daylight saving changes do not shift the buckets.
+func (p Profile) bucket(t time.Time) int {
+	h, m, sec := t.Clock()
+	tod := time.Duration(h)*time.Hour + time.Duration(m)*time.Minute +
+		time.Duration(sec)*time.Second + time.Duration(t.Nanosecond())
+	return int(tod / p.Bucket)
+}
@@ -0,0 +1,83 @@
+package gostat
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestIntradayProfile(t *testing.T) {
+	day1 := time.Date(2017, 3, 1, 0, 0, 0, 0, time.UTC)
+	day2 := day1.AddDate(0, 0, 1)
+	at := func(d time.Time, h, m int) time.Time {
+		return d.Add(time.Duration(h)*time.Hour + time.Duration(m)*time.Minute)
+	}
+	times := []time.Time{
+		at(day1, 9, 30), at(day1, 9, 45), at(day1, 10, 15), at(day1, 15, 50),
+		at(day2, 9, 35), at(day2, 10, 0), at(day2, 15, 40), at(day2, 15, 55),
+	}
+	values := []float64{4., 2., 1., 3., 6., 1., 5., math.NaN()}
+	p, err := IntradayProfile(values, times, 30*time.Minute, Median)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if got, want := len(p.Values), 48; got != want {
+		t.Fatalf("Expected number of buckets=%d, got=%d", want, got)
+	}
+	if got, want := p.Values[19], 4.; !floatEquals(got, want) {
+		t.Errorf("Expected 9:30 bucket=%f, got=%f", want, got)
+	}
+	if got, want := p.Values[20], 1.; !floatEquals(got, want) {
+		t.Errorf("Expected 10:00 bucket=%f, got=%f", want, got)
+	}
+	if got, want := p.At(at(day1, 15, 35)), 4.; !floatEquals(got, want) {
+		t.Errorf("Expected 15:30 bucket=%f, got=%f", want, got)
+	}
+	if got := p.Values[0]; !math.IsNaN(got) {
+		t.Errorf("Expected empty bucket=NaN, got=%f", got)
+	}
+
+	d, err := p.Deseasonalize(values[:3], times[:3])
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	compareArrays([]float64{1., 0.5, 1.}, d, t)
+}
+
+func TestIntradayProfile_DaylightSaving(t *testing.T) {
+	ny, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skipf("time zone database unavailable: %v", err)
+	}
+	// clocks spring forward at 2:00 on 12 March 2017 and fall back at 2:00
+	// on 5 November 2017
+	times := []time.Time{
+		time.Date(2017, 3, 12, 12, 0, 0, 0, ny),
+		time.Date(2017, 11, 5, 12, 0, 0, 0, ny),
+		time.Date(2017, 11, 5, 23, 30, 0, 0, ny),
+	}
+	p, err := IntradayProfile([]float64{1., 2., 3.}, times, time.Hour, Median)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if got, want := p.Values[12], 1.5; !floatEquals(got, want) {
+		t.Errorf("Expected 12:00 bucket=%f, got=%f", want, got)
+	}
+	if got, want := p.Values[23], 3.; !floatEquals(got, want) {
+		t.Errorf("Expected 23:00 bucket=%f, got=%f", want, got)
+	}
+	for _, b := range []int{11, 13} {
+		if got := p.Values[b]; !math.IsNaN(got) {
+			t.Errorf("Expected bucket %d=NaN, got=%f", b, got)
+		}
+	}
+}
+
+func TestIntradayProfile_Errors(t *testing.T) {
+	if _, err := IntradayProfile([]float64{1.}, nil, time.Hour, Median); err != ErrLengthMismatch {
+		t.Errorf("Expected error=%v, got=%v", ErrLengthMismatch, err)
+	}
+	if _, err := IntradayProfile(nil, nil, 0, Median); err != ErrInvalidParameter {
+		t.Errorf("Expected error=%v, got=%v", ErrInvalidParameter, err)
+	}
+}
@@ -0,0 +1,157 @@
+package gostat
+
+import (
+	"math"
+)
+
+// Segment is a linear piece of a segmented regression, fitted to the
+// observations in the half-open index range [Start, End).
+type Segment struct {
+	Start, End       int
+	Intercept, Slope float64
+}
+
+// Len returns the number of observations in the segment.
+func (s Segment) Len() int {
+	return s.End - s.Start
+}
+
+// SegmentedRegression fits a piecewise linear trend to y on the covariate x,
+// or on the observation index when x is nil, for example to summarize a long
+// price history as a few regimes of steady growth or decline. Each segment
+// has its own least squares line, and segments need not join at the breaks.
+//
+// For every number of segments up to maxSegments the breaks minimizing the
+// residual sum of squares are found by dynamic programming over segments of
+// at least minLen observations, and the number of segments with the lowest
+// Bayesian information criterion is selected, counting the two coefficients
+// of each segment, each break and the variance as parameters.
+func SegmentedRegression(x, y []float64, maxSegments, minLen int) ([]Segment, error) {
+	if maxSegments < 1 || minLen < 2 {
+		return nil, ErrInvalidParameter
+	}
+	if err := checkInput(y, minLen); err != nil {
+		return nil, err
+	}
+	if x != nil && len(x) != len(y) {
+		return nil, ErrLengthMismatch
+	}
+	n := len(y)
+	maxSegments = minInt(maxSegments, n/minLen)
+	fit := newSegmentFit(x, y)
+
+	// cost[m][j] is the smallest residual sum of squares of the first j
+	// observations split into m+1 segments, which start at from[m][j]
+	cost := make([][]float64, maxSegments)
+	from := make([][]int, maxSegments)
+	for m := 0; m < maxSegments; m++ {
+		cost[m] = make([]float64, n+1)
+		from[m] = make([]int, n+1)
+		for j := 0; j <= n; j++ {
+			cost[m][j] = math.Inf(1)
+			if m == 0 {
+				if j >= minLen {
+					cost[m][j] = fit.rss(0, j)
+				}
+				continue
+			}
+			for i := m * minLen; i <= j-minLen; i++ {
+				if c := cost[m-1][i] + fit.rss(i, j); c < cost[m][j] {
+					cost[m][j], from[m][j] = c, i
+				}
+			}
+		}
+	}
+
+	// floor the residuals so that a perfect fit does not score -Inf
+	floor := 1e-12 * math.Max(fit.rss(0, n), 1)
+	best, bestBIC := 0, math.Inf(1)
+	for m := 0; m < maxSegments; m++ {
+		rss := math.Max(cost[m][n], floor)
+		bic := float64(n)*math.Log(rss/float64(n)) + float64(3*(m+1))*math.Log(float64(n))
+		if bic < bestBIC {
+			best, bestBIC = m, bic
+		}
+	}
+
+	segments := make([]Segment, best+1)
+	end := n
+	for m := best; m >= 0; m-- {
+		start := 0
+		if m > 0 {
+			start = from[m][end]
+		}
+		intercept, slope := fit.line(start, end)
+		segments[m] = Segment{Start: start, End: end, Intercept: intercept, Slope: slope}
+		end = start
+	}
+	return segments, nil
+}
+
+// segmentFit holds the prefix sums of a series and its covariate, shifted by
+// their first values to limit cancellation, which give the least squares
+// line of any range of observations in constant time.
+type segmentFit struct {
+	x0, y0                float64
+	sx, sy, sxx, sxy, syy []float64
+}
+
+func newSegmentFit(x, y []float64) *segmentFit {
+	n := len(y)
+	f := &segmentFit{
+		y0:  y[0],
+		sx:  make([]float64, n+1),
+		sy:  make([]float64, n+1),
+		sxx: make([]float64, n+1),
+		sxy: make([]float64, n+1),
+		syy: make([]float64, n+1),
+	}
+	if x != nil {
+		f.x0 = x[0]
+	}
+	for i := 0; i < n; i++ {
+		xi := float64(i)
+		if x != nil {
+			xi = x[i] - f.x0
+		}
+		yi := y[i] - f.y0
+		f.sx[i+1] = f.sx[i] + xi
+		f.sy[i+1] = f.sy[i] + yi
+		f.sxx[i+1] = f.sxx[i] + xi*xi
+		f.sxy[i+1] = f.sxy[i] + xi*yi
+		f.syy[i+1] = f.syy[i] + yi*yi
+	}
+	return f
+}
+
+// moments returns the centered sums of squares and cross products and the
+// means of the shifted observations in [i, j).
+func (f *segmentFit) moments(i, j int) (sxx, sxy, syy, mx, my float64) {
+	m := float64(j - i)
+	mx = (f.sx[j] - f.sx[i]) / m
+	my = (f.sy[j] - f.sy[i]) / m
+	sxx = f.sxx[j] - f.sxx[i] - m*mx*mx
+	sxy = f.sxy[j] - f.sxy[i] - m*mx*my
+	syy = f.syy[j] - f.syy[i] - m*my*my
+	return sxx, sxy, syy, mx, my
+}
+
+// rss returns the residual sum of squares of the line fitted to [i, j).
+func (f *segmentFit) rss(i, j int) float64 {
+	sxx, sxy, syy, _, _ := f.moments(i, j)
+	if sxx > 0 {
+		syy -= sxy * sxy / sxx
+	}
+	return math.Max(syy, 0)
+}
+
+// line returns the intercept and the slope of the line fitted to [i, j) in
+// the original units, with a zero slope when the covariate is constant.
+func (f *segmentFit) line(i, j int) (float64, float64) {
+	sxx, sxy, _, mx, my := f.moments(i, j)
+	var slope float64
+	if sxx > 0 {
+		slope = sxy / sxx
+	}
+	return my + f.y0 - slope*(mx+f.x0), slope
+}
@@ -0,0 +1,71 @@
+package gostat
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestSegmentedRegression(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	y := make([]float64, 90)
+	for i := 0; i < len(y); i++ {
+		switch {
+		case i < 30:
+			y[i] = 10 + 0.5*float64(i)
+		case i < 60:
+			y[i] = 40 - float64(i-30)
+		default:
+			y[i] = 5 + 2*float64(i-60)
+		}
+		y[i] += 0.1 * rnd.NormFloat64()
+	}
+	segments, err := SegmentedRegression(nil, y, 5, 5)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(segments) != 3 {
+		t.Fatalf("Expected 3 segments, got=%v", segments)
+	}
+	want := []Segment{{0, 30, 10., 0.5}, {30, 60, 70., -1.}, {60, 90, -115., 2.}}
+	for i, s := range segments {
+		if s.Start != want[i].Start || s.End != want[i].End {
+			t.Errorf("Expected segment [%d, %d), got=[%d, %d)", want[i].Start, want[i].End, s.Start, s.End)
+		}
+		if got := s.Slope; got < want[i].Slope-0.01 || got > want[i].Slope+0.01 {
+			t.Errorf("Expected slope=%f, got=%f", want[i].Slope, got)
+		}
+		if got := s.Intercept; got < want[i].Intercept-0.5 || got > want[i].Intercept+0.5 {
+			t.Errorf("Expected intercept=%f, got=%f", want[i].Intercept, got)
+		}
+	}
+}
+
+func TestSegmentedRegression_Line(t *testing.T) {
+	x := []float64{0., 2., 4., 6., 8., 10.}
+	y := []float64{1., 2., 3., 4., 5., 6.}
+	segments, err := SegmentedRegression(x, y, 3, 2)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(segments) != 1 || segments[0].Len() != 6 {
+		t.Fatalf("Expected a single segment, got=%v", segments)
+	}
+	if got, want := segments[0].Intercept, 1.; !floatEquals(got, want) {
+		t.Errorf("Expected intercept=%f, got=%f", want, got)
+	}
+	if got, want := segments[0].Slope, 0.5; !floatEquals(got, want) {
+		t.Errorf("Expected slope=%f, got=%f", want, got)
+	}
+}
+
+func TestSegmentedRegression_Errors(t *testing.T) {
+	if _, err := SegmentedRegression(nil, []float64{1., 2., 3.}, 2, 1); err != ErrInvalidParameter {
+		t.Errorf("Expected error=%v, got=%v", ErrInvalidParameter, err)
+	}
+	if _, err := SegmentedRegression(nil, []float64{1.}, 2, 2); err != ErrEmptyInput {
+		t.Errorf("Expected error=%v, got=%v", ErrEmptyInput, err)
+	}
+	if _, err := SegmentedRegression([]float64{1.}, []float64{1., 2.}, 2, 2); err != ErrLengthMismatch {
+		t.Errorf("Expected error=%v, got=%v", ErrLengthMismatch, err)
+	}
+}
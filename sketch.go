@@ -0,0 +1,251 @@
+package gostat
+
+import (
+	"bytes"
+	"encoding/binary"
+	"math"
+	"sort"
+)
+
+// DDSketch is a quantile sketch with relative accuracy guarantees, which
+// suits heavy-tailed data such as latencies. Values are counted in buckets
+// whose bounds grow geometrically, so that any quantile returned is within
+// a relative error alpha of the exact value. The number of buckets can be
+// capped, in which case the buckets of the lowest values are collapsed once
+// the cap is reached, keeping the guarantee for the upper quantiles.
+//
+// Sketches with the same accuracy can be merged, and the state can be
+// serialized with MarshalBinary.
+type DDSketch struct {
+	alpha    float64
+	gamma    float64
+	logGamma float64
+	maxBins  int
+
+	pos, neg map[int]float64
+	zero     float64
+	count    float64
+	sum      float64
+	min, max float64
+}
+
+// NewDDSketch returns an empty sketch with relative accuracy alpha in
+// (0, 1), keeping at most maxBins buckets, or any number of buckets when
+// maxBins is 0. A sketch of values of both signs keeps at least one bucket
+// for each sign.
+func NewDDSketch(alpha float64, maxBins int) (*DDSketch, error) {
+	if !(alpha > 0 && alpha < 1) || maxBins < 0 {
+		return nil, ErrInvalidParameter
+	}
+	gamma := (1 + alpha) / (1 - alpha)
+	return &DDSketch{
+		alpha:    alpha,
+		gamma:    gamma,
+		logGamma: math.Log(gamma),
+		maxBins:  maxBins,
+		pos:      make(map[int]float64),
+		neg:      make(map[int]float64),
+		min:      math.Inf(1),
+		max:      math.Inf(-1),
+	}, nil
+}
+
+// Add adds a value to the sketch. NaN and infinite values are ignored.
+func (s *DDSketch) Add(x float64) {
+	s.AddN(x, 1)
+}
+
+// AddN adds a value with a positive count, or weight, to the sketch.
+func (s *DDSketch) AddN(x, n float64) {
+	if !isRealVal(x) || !(n > 0) {
+		return
+	}
+	switch {
+	case x > 0:
+		s.pos[s.key(x)] += n
+	case x < 0:
+		s.neg[s.key(-x)] += n
+	default:
+		s.zero += n
+	}
+	s.count += n
+	s.sum += x * n
+	s.min = math.Min(s.min, x)
+	s.max = math.Max(s.max, x)
+	s.collapse()
+}
+
+// Count returns the total count of the values added to the sketch.
+func (s *DDSketch) Count() float64 {
+	return s.count
+}
+
+// Mean returns the exact mean of the values added to the sketch, NaN when
+// it is empty.
+func (s *DDSketch) Mean() float64 {
+	if s.count == 0 {
+		return math.NaN()
+	}
+	return s.sum / s.count
+}
+
+// Quantile returns an estimate of the p-quantile of the values added to the
+// sketch, within the relative accuracy of the sketch. NaN is returned for
+// an empty sketch or p outside of [0, 1].
+func (s *DDSketch) Quantile(p float64) float64 {
+	if s.count == 0 || !(p >= 0 && p <= 1) {
+		return math.NaN()
+	}
+	rank := p * (s.count - 1)
+	var cum float64
+	var value float64
+	found := false
+	for _, k := range sortedKeys(s.neg, true) {
+		if cum += s.neg[k]; cum > rank {
+			value, found = -s.value(k), true
+			break
+		}
+	}
+	if !found {
+		if cum += s.zero; cum > rank {
+			value, found = 0, true
+		}
+	}
+	if !found {
+		value = s.max
+		for _, k := range sortedKeys(s.pos, false) {
+			if cum += s.pos[k]; cum > rank {
+				value = s.value(k)
+				break
+			}
+		}
+	}
+	return math.Max(s.min, math.Min(s.max, value))
+}
+
+// Merge adds the values counted by other to the sketch. Both sketches must
+// have the same relative accuracy, otherwise ErrInvalidParameter is
+// returned.
+func (s *DDSketch) Merge(other *DDSketch) error {
+	if other.gamma != s.gamma {
+		return ErrInvalidParameter
+	}
+	for k, n := range other.pos {
+		s.pos[k] += n
+	}
+	for k, n := range other.neg {
+		s.neg[k] += n
+	}
+	s.zero += other.zero
+	s.count += other.count
+	s.sum += other.sum
+	s.min = math.Min(s.min, other.min)
+	s.max = math.Max(s.max, other.max)
+	s.collapse()
+	return nil
+}
+
+// MarshalBinary encodes the state of the sketch.
+func (s *DDSketch) MarshalBinary() ([]byte, error) {
+	var buf bytes.Buffer
+	header := []float64{s.alpha, float64(s.maxBins), s.zero, s.count, s.sum, s.min, s.max}
+	if err := binary.Write(&buf, binary.LittleEndian, header); err != nil {
+		return nil, err
+	}
+	for _, store := range []map[int]float64{s.pos, s.neg} {
+		keys := sortedKeys(store, false)
+		if err := binary.Write(&buf, binary.LittleEndian, int64(len(keys))); err != nil {
+			return nil, err
+		}
+		for _, k := range keys {
+			if err := binary.Write(&buf, binary.LittleEndian, int64(k)); err != nil {
+				return nil, err
+			}
+			if err := binary.Write(&buf, binary.LittleEndian, store[k]); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return buf.Bytes(), nil
+}
+
+// UnmarshalBinary decodes the state of a sketch encoded by MarshalBinary,
+// replacing the state of s.
+func (s *DDSketch) UnmarshalBinary(data []byte) error {
+	r := bytes.NewReader(data)
+	header := make([]float64, 7)
+	if err := binary.Read(r, binary.LittleEndian, header); err != nil {
+		return err
+	}
+	d, err := NewDDSketch(header[0], int(header[1]))
+	if err != nil {
+		return err
+	}
+	d.zero, d.count, d.sum, d.min, d.max = header[2], header[3], header[4], header[5], header[6]
+	for _, store := range []map[int]float64{d.pos, d.neg} {
+		var n int64
+		if err := binary.Read(r, binary.LittleEndian, &n); err != nil {
+			return err
+		}
+		for i := int64(0); i < n; i++ {
+			var k int64
+			var c float64
+			if err := binary.Read(r, binary.LittleEndian, &k); err != nil {
+				return err
+			}
+			if err := binary.Read(r, binary.LittleEndian, &c); err != nil {
+				return err
+			}
+			store[int(k)] = c
+		}
+	}
+	*s = *d
+	return nil
+}
+
+// key returns the index of the bucket (gamma^(k-1), gamma^k] of x > 0.
+func (s *DDSketch) key(x float64) int {
+	return int(math.Ceil(math.Log(x) / s.logGamma))
+}
+
+// value returns the representative value of bucket k, which is within the
+// relative accuracy of every value in the bucket.
+func (s *DDSketch) value(k int) float64 {
+	return 2 * math.Pow(s.gamma, float64(k)) / (s.gamma + 1)
+}
+
+// collapse merges the buckets of the lowest values until the number of
+// buckets is within maxBins.
+func (s *DDSketch) collapse() {
+	if s.maxBins == 0 {
+		return
+	}
+	for len(s.pos)+len(s.neg) > s.maxBins {
+		switch {
+		case len(s.neg) > 1:
+			// the most negative values have the highest keys
+			keys := sortedKeys(s.neg, true)
+			s.neg[keys[1]] += s.neg[keys[0]]
+			delete(s.neg, keys[0])
+		case len(s.pos) > 1:
+			keys := sortedKeys(s.pos, false)
+			s.pos[keys[1]] += s.pos[keys[0]]
+			delete(s.pos, keys[0])
+		default:
+			return
+		}
+	}
+}
+
+func sortedKeys(m map[int]float64, desc bool) []int {
+	keys := make([]int, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	if desc {
+		sort.Sort(sort.Reverse(sort.IntSlice(keys)))
+	} else {
+		sort.Ints(keys)
+	}
+	return keys
+}
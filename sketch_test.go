@@ -0,0 +1,117 @@
+package gostat
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func TestDDSketch_Quantile(t *testing.T) {
+	s, err := NewDDSketch(0.01, 0)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	rng := rand.New(rand.NewSource(1))
+	x := make([]float64, 10000)
+	for i := 0; i < len(x); i++ {
+		x[i] = math.Exp(rng.NormFloat64())
+		s.Add(x[i])
+	}
+	if got, want := s.Count(), float64(len(x)); got != want {
+		t.Errorf("Expected count=%f, got=%f", want, got)
+	}
+	for _, p := range []float64{0., 0.1, 0.5, 0.9, 0.99, 1.} {
+		want := Quantile(x, p, QuantileLower)
+		if got := s.Quantile(p); math.Abs(got-want) > 0.01*want*1.0001 {
+			t.Errorf("Expected quantile p=%f within 1%% of %f, got=%f", p, want, got)
+		}
+	}
+	if got := s.Quantile(1.5); !math.IsNaN(got) {
+		t.Errorf("Expected quantile=NaN, got=%f", got)
+	}
+}
+
+func TestDDSketch_Negative(t *testing.T) {
+	s, _ := NewDDSketch(0.02, 0)
+	x := []float64{-100., -10., -1., 0., 1., 10., 100.}
+	for _, v := range x {
+		s.Add(v)
+	}
+	for i, v := range x {
+		p := float64(i) / float64(len(x)-1)
+		if got := s.Quantile(p); math.Abs(got-v) > 0.02*math.Abs(v)*1.0001 {
+			t.Errorf("Expected quantile p=%f within 2%% of %f, got=%f", p, v, got)
+		}
+	}
+	if got, want := s.Mean(), 0.; got != want {
+		t.Errorf("Expected mean=%f, got=%f", want, got)
+	}
+}
+
+func TestDDSketch_MaxBins(t *testing.T) {
+	s, _ := NewDDSketch(0.01, 50)
+	for i := 1; i <= 100000; i++ {
+		s.Add(float64(i))
+	}
+	if got := len(s.pos) + len(s.neg); got > 50 {
+		t.Errorf("Expected at most 50 buckets, got=%d", got)
+	}
+	if got, want := s.Quantile(0.99), 99000.; math.Abs(got-want) > 0.01*want*1.0001 {
+		t.Errorf("Expected quantile within 1%% of %f, got=%f", want, got)
+	}
+}
+
+func TestDDSketch_Merge(t *testing.T) {
+	a, _ := NewDDSketch(0.01, 0)
+	b, _ := NewDDSketch(0.01, 0)
+	all, _ := NewDDSketch(0.01, 0)
+	for i := 1; i <= 1000; i++ {
+		if i%2 == 0 {
+			a.Add(float64(i))
+		} else {
+			b.Add(float64(i))
+		}
+		all.Add(float64(i))
+	}
+	if err := a.Merge(b); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	for _, p := range []float64{0.1, 0.5, 0.9} {
+		if got, want := a.Quantile(p), all.Quantile(p); got != want {
+			t.Errorf("Expected quantile p=%f=%f, got=%f", p, want, got)
+		}
+	}
+	c, _ := NewDDSketch(0.05, 0)
+	if err := a.Merge(c); err != ErrInvalidParameter {
+		t.Errorf("Expected error=%v, got=%v", ErrInvalidParameter, err)
+	}
+}
+
+func TestDDSketch_Marshal(t *testing.T) {
+	s, _ := NewDDSketch(0.01, 100)
+	for _, v := range []float64{-3., 0., 0.5, 2., 7., 1000.} {
+		s.Add(v)
+	}
+	data, err := s.MarshalBinary()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	var d DDSketch
+	if err := d.UnmarshalBinary(data); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	for _, p := range []float64{0., 0.25, 0.5, 0.75, 1.} {
+		if got, want := d.Quantile(p), s.Quantile(p); got != want {
+			t.Errorf("Expected quantile p=%f=%f, got=%f", p, want, got)
+		}
+	}
+	if err := d.UnmarshalBinary(data[:10]); err == nil {
+		t.Errorf("Expected error for truncated data")
+	}
+}
+
+func TestNewDDSketch_Invalid(t *testing.T) {
+	if _, err := NewDDSketch(1., 0); err != ErrInvalidParameter {
+		t.Errorf("Expected error=%v, got=%v", ErrInvalidParameter, err)
+	}
+}
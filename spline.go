@@ -0,0 +1,282 @@
+package gostat
+
+import (
+	"math"
+)
+
+// SmoothingSpline is a natural cubic smoothing spline, returned by
+// FitSmoothingSpline.
+type SmoothingSpline struct {
+	// Knots are the covariate values of the observations.
+	Knots []float64
+	// Fitted holds the values of the spline at the knots.
+	Fitted []float64
+	// Lambda is the smoothing parameter, the weight of the roughness
+	// penalty.
+	Lambda float64
+	// DF is the effective degrees of freedom of the fit, the trace of its
+	// hat matrix, from 2 for a straight line to the number of knots for
+	// interpolation.
+	DF float64
+	// second holds the second derivatives at the knots.
+	second []float64
+}
+
+// FitSmoothingSpline fits a cubic smoothing spline to y on the covariate x,
+// or on the observation index when x is nil, as a nonparametric estimate of
+// the trend of y. The spline minimizes the weighted residual sum of squares
+// plus lambda times the integral of its squared second derivative, so that
+// it interpolates y when lambda is zero and tends to the least squares line
+// as lambda grows. A negative lambda is selected by generalized
+// cross-validation. The values of x must be strictly increasing, and the
+// observations are weighted by weights, or equally when weights is nil.
+//
+// The fit is found in O(n) time for a given lambda by the Reinsch algorithm.
+func FitSmoothingSpline(x, y, weights []float64, lambda float64) (SmoothingSpline, error) {
+	var s SmoothingSpline
+	n := len(y)
+	if (x != nil && len(x) != n) || (weights != nil && len(weights) != n) {
+		return s, ErrLengthMismatch
+	}
+	if n < 3 {
+		return s, ErrEmptyInput
+	}
+	knots := x
+	if knots == nil {
+		knots = make([]float64, n)
+		for i := 0; i < n; i++ {
+			knots[i] = float64(i)
+		}
+	}
+	for i := 0; i < n; i++ {
+		if !isRealVal(y[i]) || (i > 0 && !(knots[i] > knots[i-1])) || (weights != nil && !(weights[i] > 0)) {
+			return s, ErrInvalidParameter
+		}
+	}
+	sp := newSplineSystem(knots, y, weights)
+	if lambda < 0 {
+		lambda = sp.selectLambda()
+	}
+	s.Knots = append([]float64{}, knots...)
+	s.Fitted, s.second, s.DF, _ = sp.solve(lambda)
+	s.Lambda = lambda
+	return s, nil
+}
+
+// At returns the value of the spline at t, extrapolated linearly beyond the
+// first and the last knot.
+func (s SmoothingSpline) At(t float64) float64 {
+	x, g, gamma := s.Knots, s.Fitted, s.second
+	n := len(x)
+	switch {
+	case t <= x[0]:
+		h := x[1] - x[0]
+		return g[0] + (t-x[0])*((g[1]-g[0])/h-h*gamma[1]/6)
+	case t >= x[n-1]:
+		h := x[n-1] - x[n-2]
+		return g[n-1] + (t-x[n-1])*((g[n-1]-g[n-2])/h+h*gamma[n-2]/6)
+	}
+	lo, hi := 0, n-1
+	for hi-lo > 1 {
+		mid := (lo + hi) / 2
+		if x[mid] <= t {
+			lo = mid
+		} else {
+			hi = mid
+		}
+	}
+	h := x[hi] - x[lo]
+	a, b := t-x[lo], x[hi]-t
+	return (a*g[hi]+b*g[lo])/h - a*b/6*((1+a/h)*gamma[hi]+(1+b/h)*gamma[lo])
+}
+
+// splineSystem holds the banded matrices of the Reinsch algorithm, R and
+// P = Q'W^-1 Q, with Q'y, where column c of Q is the second divided
+// difference at the interior knot c+1.
+type splineSystem struct {
+	y, w       []float64
+	q          [][3]float64
+	r0, r1     []float64
+	p0, p1, p2 []float64
+	qy         []float64
+}
+
+func newSplineSystem(x, y, weights []float64) *splineSystem {
+	n := len(x)
+	m := n - 2
+	sp := &splineSystem{
+		y:  y,
+		w:  weights,
+		q:  make([][3]float64, m),
+		r0: make([]float64, m),
+		r1: make([]float64, m),
+		p0: make([]float64, m),
+		p1: make([]float64, m),
+		p2: make([]float64, m),
+		qy: make([]float64, m),
+	}
+	for c := 0; c < m; c++ {
+		h0, h1 := x[c+1]-x[c], x[c+2]-x[c+1]
+		sp.q[c] = [3]float64{1 / h0, -1/h0 - 1/h1, 1 / h1}
+		sp.r0[c] = (h0 + h1) / 3
+		sp.r1[c] = h1 / 3
+		sp.qy[c] = (y[c+2]-y[c+1])/h1 - (y[c+1]-y[c])/h0
+	}
+	for c := 0; c < m; c++ {
+		for k := 0; k < 3; k++ {
+			sp.p0[c] += sp.q[c][k] * sp.q[c][k] / sp.weight(c+k)
+		}
+		if c+1 < m {
+			sp.p1[c] = sp.q[c][1]*sp.q[c+1][0]/sp.weight(c+1) + sp.q[c][2]*sp.q[c+1][1]/sp.weight(c+2)
+		}
+		if c+2 < m {
+			sp.p2[c] = sp.q[c][2] * sp.q[c+2][0] / sp.weight(c+2)
+		}
+	}
+	return sp
+}
+
+func (sp *splineSystem) weight(i int) float64 {
+	if sp.w == nil {
+		return 1
+	}
+	return sp.w[i]
+}
+
+// solve returns the fitted values, the second derivatives at the knots, the
+// trace of the hat matrix and the generalized cross-validation score of the
+// spline with smoothing parameter lambda.
+func (sp *splineSystem) solve(lambda float64) ([]float64, []float64, float64, float64) {
+	m := len(sp.qy)
+	n := m + 2
+
+	// LDL' factorization of the pentadiagonal M = R + lambda*P
+	d := make([]float64, m)
+	l1 := make([]float64, m)
+	l2 := make([]float64, m)
+	for i := 0; i < m; i++ {
+		if i >= 2 {
+			l2[i] = lambda * sp.p2[i-2] / d[i-2]
+		}
+		if i >= 1 {
+			l1[i] = sp.r1[i-1] + lambda*sp.p1[i-1]
+			if i >= 2 {
+				l1[i] -= l2[i] * l1[i-1] * d[i-2]
+			}
+			l1[i] /= d[i-1]
+		}
+		d[i] = sp.r0[i] + lambda*sp.p0[i]
+		if i >= 1 {
+			d[i] -= l1[i] * l1[i] * d[i-1]
+		}
+		if i >= 2 {
+			d[i] -= l2[i] * l2[i] * d[i-2]
+		}
+	}
+
+	gamma := make([]float64, m)
+	for i := 0; i < m; i++ {
+		gamma[i] = sp.qy[i]
+		if i >= 1 {
+			gamma[i] -= l1[i] * gamma[i-1]
+		}
+		if i >= 2 {
+			gamma[i] -= l2[i] * gamma[i-2]
+		}
+	}
+	for i := m - 1; i >= 0; i-- {
+		gamma[i] /= d[i]
+		if i+1 < m {
+			gamma[i] -= l1[i+1] * gamma[i+1]
+		}
+		if i+2 < m {
+			gamma[i] -= l2[i+2] * gamma[i+2]
+		}
+	}
+
+	fitted := make([]float64, n)
+	second := make([]float64, n)
+	copy(fitted, sp.y)
+	for c := 0; c < m; c++ {
+		second[c+1] = gamma[c]
+		for k := 0; k < 3; k++ {
+			fitted[c+k] -= lambda * sp.q[c][k] * gamma[c] / sp.weight(c+k)
+		}
+	}
+
+	// the band of M^-1 from the factorization, by the recursion of
+	// Hutchinson and de Hoog, gives the trace of the hat matrix
+	// I - lambda*W^-1 Q M^-1 Q'
+	var row1, row2 [3]float64 // S[j][j], S[j][j+1] and S[j][j+2] of rows i+1 and i+2
+	var tr float64
+	for i := m - 1; i >= 0; i-- {
+		var a1, a2 float64
+		if i+1 < m {
+			a1 = l1[i+1]
+		}
+		if i+2 < m {
+			a2 = l2[i+2]
+		}
+		sii1 := -a1*row1[0] - a2*row1[1]
+		sii2 := -a1*row1[1] - a2*row2[0]
+		sii := 1/d[i] - a1*sii1 - a2*sii2
+		row2, row1 = row1, [3]float64{sii, sii1, sii2}
+		tr += sii*sp.p0[i] + 2*sii1*sp.p1[i] + 2*sii2*sp.p2[i]
+	}
+	df := float64(n) - lambda*tr
+
+	var rss neumaierSum
+	for i := 0; i < n; i++ {
+		r := sp.y[i] - fitted[i]
+		rss.add(sp.weight(i) * r * r)
+	}
+	gcv := rss.total() / float64(n) / math.Pow(1-df/float64(n), 2)
+	return fitted, second, df, gcv
+}
+
+// selectLambda returns the smoothing parameter minimizing the generalized
+// cross-validation score, searched on a logarithmic grid scaled to the ratio
+// of the traces of R and P, then refined by golden section search.
+func (sp *splineSystem) selectLambda() float64 {
+	var trR, trP float64
+	for c := 0; c < len(sp.r0); c++ {
+		trR += sp.r0[c]
+		trP += sp.p0[c]
+	}
+	ratio := trR / trP
+	lambdaAt := func(s float64) float64 {
+		return ratio * math.Pow(256, 3*s-1)
+	}
+	score := func(s float64) float64 {
+		_, _, _, gcv := sp.solve(lambdaAt(s))
+		if math.IsNaN(gcv) {
+			return math.Inf(1)
+		}
+		return gcv
+	}
+
+	const lo, hi, steps = -1.5, 1.5, 60
+	best, bestScore := lo, math.Inf(1)
+	for k := 0; k <= steps; k++ {
+		s := lo + (hi-lo)*float64(k)/steps
+		if v := score(s); v < bestScore {
+			best, bestScore = s, v
+		}
+	}
+	a, b := best-(hi-lo)/steps, best+(hi-lo)/steps
+	phi := (math.Sqrt(5) - 1) / 2
+	c, e := b-phi*(b-a), a+phi*(b-a)
+	fc, fe := score(c), score(e)
+	for k := 0; k < 40; k++ {
+		if fc < fe {
+			b, e, fe = e, c, fc
+			c = b - phi*(b-a)
+			fc = score(c)
+		} else {
+			a, c, fc = c, e, fe
+			e = a + phi*(b-a)
+			fe = score(e)
+		}
+	}
+	return lambdaAt((a + b) / 2)
+}
@@ -0,0 +1,86 @@
+package gostat
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func TestFitSmoothingSpline_Interpolate(t *testing.T) {
+	x := []float64{0., 1., 2.5, 3., 5.}
+	y := []float64{1., 3., 2., 4., 0.}
+	s, err := FitSmoothingSpline(x, y, nil, 0)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	compareArrays(y, s.Fitted, t)
+	for i := 0; i < len(x); i++ {
+		if got, want := s.At(x[i]), y[i]; !floatEquals(got, want) {
+			t.Errorf("Expected spline at %f=%f, got=%f", x[i], want, got)
+		}
+	}
+	if got, want := s.DF, 5.; !floatEquals(got, want) {
+		t.Errorf("Expected DF=%f, got=%f", want, got)
+	}
+}
+
+func TestFitSmoothingSpline_Line(t *testing.T) {
+	y := []float64{1., 2.5, 2.8, 4.5, 5., 6.2, 7.1}
+	s, err := FitSmoothingSpline(nil, y, nil, 1e9)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	coef, _ := leastSquares(y, [][]float64{{0., 1., 2., 3., 4., 5., 6.}}, nil)
+	for i := 0; i < len(y); i++ {
+		if got, want := s.Fitted[i], coef[0]+coef[1]*float64(i); !floatEquals(got, want) {
+			t.Errorf("Expected fitted value at %d=%f, got=%f", i, want, got)
+		}
+	}
+	if got, want := s.At(10), coef[0]+10*coef[1]; !floatEquals(got, want) {
+		t.Errorf("Expected extrapolated value=%f, got=%f", want, got)
+	}
+	if got, want := s.DF, 2.; !floatEquals(got, want) {
+		t.Errorf("Expected DF=%f, got=%f", want, got)
+	}
+}
+
+func TestFitSmoothingSpline_CrossValidated(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	n := 200
+	x := make([]float64, n)
+	y := make([]float64, n)
+	for i := 0; i < n; i++ {
+		x[i] = 2 * math.Pi * float64(i) / float64(n)
+		y[i] = math.Sin(x[i]) + 0.2*rnd.NormFloat64()
+	}
+	s, err := FitSmoothingSpline(x, y, nil, -1)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if s.DF < 3 || s.DF > 15 {
+		t.Errorf("Expected a smooth fit, got DF=%f", s.DF)
+	}
+	var sse float64
+	for i := 0; i < n; i++ {
+		d := s.Fitted[i] - math.Sin(x[i])
+		sse += d * d
+	}
+	if rmse := math.Sqrt(sse / float64(n)); rmse > 0.08 {
+		t.Errorf("Expected fit close to the trend, got RMSE=%f", rmse)
+	}
+	if got, want := s.At(math.Pi/2), 1.; math.Abs(got-want) > 0.1 {
+		t.Errorf("Expected spline at pi/2 near %f, got=%f", want, got)
+	}
+}
+
+func TestFitSmoothingSpline_Errors(t *testing.T) {
+	if _, err := FitSmoothingSpline(nil, []float64{1., 2.}, nil, 1); err != ErrEmptyInput {
+		t.Errorf("Expected error=%v, got=%v", ErrEmptyInput, err)
+	}
+	if _, err := FitSmoothingSpline([]float64{0., 2., 1.}, []float64{1., 2., 3.}, nil, 1); err != ErrInvalidParameter {
+		t.Errorf("Expected error=%v, got=%v", ErrInvalidParameter, err)
+	}
+	if _, err := FitSmoothingSpline(nil, []float64{1., 2., 3.}, []float64{1.}, 1); err != ErrLengthMismatch {
+		t.Errorf("Expected error=%v, got=%v", ErrLengthMismatch, err)
+	}
+}
@@ -0,0 +1,157 @@
+package gostat
+
+import (
+	"math/rand"
+	"sort"
+)
+
+// Split is a pair of training and test sets given as half-open index ranges
+// [TrainStart, TrainEnd) and [TestStart, TestEnd) into a series.
+type Split struct {
+	TrainStart, TrainEnd int
+	TestStart, TestEnd   int
+}
+
+// WalkForwardOpts controls how WalkForward splits a series.
+type WalkForwardOpts struct {
+	// Train is the length of the first training set, and of every
+	// training set unless Expanding is set.
+	Train int
+	// Test is the length of each test set.
+	Test int
+	// Step is the distance by which the origin moves forward between
+	// splits, Test when zero so that the test sets do not overlap.
+	Step int
+	// Gap is the number of observations left out between the end of the
+	// training set and the start of the test set, to keep autocorrelated
+	// observations of the test set from leaking into training.
+	Gap int
+	// Expanding keeps the training sets anchored at the start of the
+	// series, growing them with the origin, instead of sliding a window of
+	// fixed length.
+	Expanding bool
+}
+
+// WalkForward returns the rolling-origin cross-validation splits of a series
+// of n observations. Every test set follows its training set, so that
+// parameters tuned on the training sets never see observations from the
+// future. Only splits with a complete test set are returned.
+func WalkForward(n int, opts WalkForwardOpts) ([]Split, error) {
+	if opts.Train < 1 || opts.Test < 1 {
+		return nil, ErrInvalidWindow
+	}
+	if opts.Step < 0 || opts.Gap < 0 {
+		return nil, ErrInvalidParameter
+	}
+	step := opts.Step
+	if step == 0 {
+		step = opts.Test
+	}
+
+	var splits []Split
+	for origin := opts.Train; origin+opts.Gap+opts.Test <= n; origin += step {
+		s := Split{
+			TrainStart: origin - opts.Train,
+			TrainEnd:   origin,
+			TestStart:  origin + opts.Gap,
+			TestEnd:    origin + opts.Gap + opts.Test,
+		}
+		if opts.Expanding {
+			s.TrainStart = 0
+		}
+		splits = append(splits, s)
+	}
+	return splits, nil
+}
+
+// Fold is a pair of training and test sets given as ascending indices into a
+// series, for cross-validation of observations that are not ordered in time.
+type Fold struct {
+	Train, Test []int
+}
+
+// KFold returns k folds of n observations. Each observation is in the test
+// set of exactly one fold, and the test sets differ in size by at most one.
+// The observations are shuffled with rng before they are assigned to folds,
+// or kept in order when rng is nil.
+func KFold(n, k int, rng *rand.Rand) ([]Fold, error) {
+	if k < 2 || k > n {
+		return nil, ErrInvalidParameter
+	}
+	idx := make([]int, n)
+	for i := 0; i < n; i++ {
+		idx[i] = i
+	}
+	shuffleIndices(idx, rng)
+
+	assign := make([]int, n)
+	pos := 0
+	for f := 0; f < k; f++ {
+		size := n / k
+		if f < n%k {
+			size++
+		}
+		for i := pos; i < pos+size; i++ {
+			assign[idx[i]] = f
+		}
+		pos += size
+	}
+	return makeFolds(assign, k), nil
+}
+
+// StratifiedKFold returns k folds of the observations with given class
+// labels, keeping the proportion of each class in every test set close to
+// its proportion in the whole sample. The observations of each class are
+// shuffled with rng before they are dealt to the folds, or kept in order
+// when rng is nil.
+func StratifiedKFold(labels []int, k int, rng *rand.Rand) ([]Fold, error) {
+	if k < 2 || k > len(labels) {
+		return nil, ErrInvalidParameter
+	}
+	classes := make(map[int][]int)
+	for i := 0; i < len(labels); i++ {
+		classes[labels[i]] = append(classes[labels[i]], i)
+	}
+	keys := make([]int, 0, len(classes))
+	for c := range classes {
+		keys = append(keys, c)
+	}
+	sort.Ints(keys)
+
+	assign := make([]int, len(labels))
+	f := 0
+	for _, c := range keys {
+		idx := classes[c]
+		shuffleIndices(idx, rng)
+		for i := 0; i < len(idx); i++ {
+			assign[idx[i]] = f
+			f = (f + 1) % k
+		}
+	}
+	return makeFolds(assign, k), nil
+}
+
+func shuffleIndices(idx []int, rng *rand.Rand) {
+	if rng == nil {
+		return
+	}
+	for i := len(idx) - 1; i > 0; i-- {
+		j := rng.Intn(i + 1)
+		idx[i], idx[j] = idx[j], idx[i]
+	}
+}
+
+// makeFolds builds k folds from the fold each observation is assigned to.
+func makeFolds(assign []int, k int) []Fold {
+	folds := make([]Fold, k)
+	for i := 0; i < len(assign); i++ {
+		for f := 0; f < k; f++ {
+			if assign[i] == f {
+				folds[f].Test = append(folds[f].Test, i)
+			} else {
+				folds[f].Train = append(folds[f].Train, i)
+			}
+		}
+	}
+	return folds
+}
@@ -0,0 +1,132 @@
+package gostat
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestWalkForward_Sliding(t *testing.T) {
+	splits, err := WalkForward(10, WalkForwardOpts{Train: 4, Test: 2})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	compareSplits([]Split{{0, 4, 4, 6}, {2, 6, 6, 8}, {4, 8, 8, 10}}, splits, t)
+}
+
+func TestWalkForward_Expanding(t *testing.T) {
+	splits, err := WalkForward(10, WalkForwardOpts{Train: 4, Test: 2, Step: 3, Gap: 1, Expanding: true})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	compareSplits([]Split{{0, 4, 5, 7}, {0, 7, 8, 10}}, splits, t)
+}
+
+func TestWalkForward_Errors(t *testing.T) {
+	if _, err := WalkForward(10, WalkForwardOpts{Train: 4}); err != ErrInvalidWindow {
+		t.Errorf("Expected error=%v, got=%v", ErrInvalidWindow, err)
+	}
+	if _, err := WalkForward(10, WalkForwardOpts{Train: 4, Test: 2, Gap: -1}); err != ErrInvalidParameter {
+		t.Errorf("Expected error=%v, got=%v", ErrInvalidParameter, err)
+	}
+	splits, err := WalkForward(5, WalkForwardOpts{Train: 4, Test: 2})
+	if err != nil || len(splits) != 0 {
+		t.Errorf("Expected no splits, got=%v (%v)", splits, err)
+	}
+}
+
+func compareSplits(want, got []Split, t *testing.T) {
+	if len(want) != len(got) {
+		t.Fatalf("Expected splits=%v, got=%v", want, got)
+	}
+	for i := 0; i < len(want); i++ {
+		if got[i] != want[i] {
+			t.Errorf("Expected split at %d=%v, got=%v", i, want[i], got[i])
+		}
+	}
+}
+
+func TestKFold(t *testing.T) {
+	folds, err := KFold(7, 3, nil)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	compareFolds([]Fold{
+		{Train: []int{3, 4, 5, 6}, Test: []int{0, 1, 2}},
+		{Train: []int{0, 1, 2, 5, 6}, Test: []int{3, 4}},
+		{Train: []int{0, 1, 2, 3, 4}, Test: []int{5, 6}},
+	}, folds, t)
+}
+
+func TestKFold_Shuffle(t *testing.T) {
+	folds, err := KFold(10, 4, rand.New(rand.NewSource(1)))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	seen := make([]int, 10)
+	for _, f := range folds {
+		if len(f.Train)+len(f.Test) != 10 {
+			t.Errorf("Expected fold of 10 observations, got=%v", f)
+		}
+		for _, i := range f.Test {
+			seen[i]++
+		}
+	}
+	for i, c := range seen {
+		if c != 1 {
+			t.Errorf("Expected observation %d in one test set, got=%d", i, c)
+		}
+	}
+}
+
+func TestStratifiedKFold(t *testing.T) {
+	labels := []int{0, 0, 0, 0, 1, 1, 0, 0, 1, 1}
+	folds, err := StratifiedKFold(labels, 2, nil)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	compareFolds([]Fold{
+		{Train: []int{1, 3, 5, 7, 9}, Test: []int{0, 2, 4, 6, 8}},
+		{Train: []int{0, 2, 4, 6, 8}, Test: []int{1, 3, 5, 7, 9}},
+	}, folds, t)
+	for _, f := range folds {
+		var ones int
+		for _, i := range f.Test {
+			ones += labels[i]
+		}
+		if ones != 2 {
+			t.Errorf("Expected 2 observations of class 1 in test set, got=%d", ones)
+		}
+	}
+}
+
+func TestKFold_Errors(t *testing.T) {
+	if _, err := KFold(3, 4, nil); err != ErrInvalidParameter {
+		t.Errorf("Expected error=%v, got=%v", ErrInvalidParameter, err)
+	}
+	if _, err := StratifiedKFold([]int{0, 1}, 1, nil); err != ErrInvalidParameter {
+		t.Errorf("Expected error=%v, got=%v", ErrInvalidParameter, err)
+	}
+}
+
+func compareFolds(want, got []Fold, t *testing.T) {
+	if len(want) != len(got) {
+		t.Fatalf("Expected folds=%v, got=%v", want, got)
+	}
+	for i := 0; i < len(want); i++ {
+		if !equalInts(want[i].Train, got[i].Train) || !equalInts(want[i].Test, got[i].Test) {
+			t.Errorf("Expected fold[%d]=%v, got=%v", i, want[i], got[i])
+		}
+	}
+}
+
+func equalInts(a, b []int) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := 0; i < len(a); i++ {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -1,7 +1,6 @@
 package gostat
 
 import (
-	"github.com/gonum/stat"
 	"math"
 	"sort"
 )
@@ -22,6 +21,9 @@ import (
 // 3. the median of the absolute deviations (from the median) is multiplied by the constant of 1.4826;
 //
 // 4. this product is defined as the MAD.
+//
+// MAD returns -1 for an empty slice, see MADE for a variant returning an
+// error instead.
 func MAD(x []float64) float64 {
 	if len(x) == 0 {
 		return -1.0
@@ -55,29 +57,98 @@ func Median(x []float64) float64 {
 // standard deviation values, where each standard deviation is calculated
 // over a sliding window of length k across neighboring elements of x.
 // Set center to true for center moving standard deviation or to false
-// for trailing moving standard deviation.
+// for trailing moving standard deviation. Each standard deviation is
+// calculated with the compensated two-pass method, see MovStdDevWith for a
+// faster incremental alternative.
 func MovStdDev(x, weights []float64, k int, omitNaNs, trailing, fullWnd bool) []float64 {
-	rolling := RollingWindow(x, k, omitNaNs, trailing, fullWnd)
-	stdDevs := make([]float64, len(rolling))
-	for i := 0; i < len(rolling); i++ {
-		stdDevs[i] = stat.StdDev(rolling[i], weights)
-	}
+	return MovStdDevWith(x, k, WindowOpts{
+		Weights:    weights,
+		OmitNaNs:   omitNaNs,
+		Trailing:   trailing,
+		FullWindow: fullWnd,
+	})
+}
 
-	return stdDevs
+// MovStdDevTo is like MovStdDev, but stores the standard deviations in dst,
+// which is reused when it has enough capacity, and returns it resliced to
+// the number of windows. No memory is allocated when dst is large enough
+// and NaN values are not omitted.
+func MovStdDevTo(dst, x, weights []float64, k int, omitNaNs, trailing, fullWnd bool) []float64 {
+	v := x
+	if omitNaNs {
+		v = filterNaNs(x)
+	}
+	it := newWindowIter(len(v), len(x), k, trailing, fullWnd)
+	dst = dst[:0]
+	for it.next() {
+		window := v[it.start:it.end]
+		var w []float64
+		if weights != nil {
+			w = weights[it.off : it.off+len(window)]
+		}
+		dst = append(dst, StdDev(window, w, VarianceTwoPass))
+	}
+	return dst
 }
 
 // Volatility calculates historical volatility as annualized standard
 // deviation of logarithmic returns
 func Volatility(x []float64, periodicity float64) float64 {
-	var rets []float64
-	// calculate returns
-	for i := 1; i < len(x); i++ {
-		rets = append(rets, math.Log(x[i]/x[i-1]))
-	}
-	stdev := stat.StdDev(rets, nil)
+	stdev := StdDev(LogReturns(x), nil, VarianceTwoPass)
 	return stdev * math.Sqrt(periodicity)
 }
 
+// ReturnFilter selects how VolatilityRobust treats outlying returns.
+type ReturnFilter int
+
+const (
+	// FilterWinsorize replaces outlying returns with the nearest of the
+	// smallest and the largest remaining return.
+	FilterWinsorize ReturnFilter = iota
+	// FilterRemove drops outlying returns.
+	FilterRemove
+)
+
+// VolatilityRobust calculates historical volatility like Volatility, after
+// filtering the logarithmic returns whose distance from the median return
+// exceeds threshold MADs, as flagged by OutliersMAD, so that a single bad
+// tick does not dominate the estimate. It also returns the indices of the
+// filtered returns, where return i is between prices i and i+1.
+func VolatilityRobust(x []float64, periodicity, threshold float64, filter ReturnFilter) (float64, []int) {
+	rets := LogReturns(x)
+	idx := OutliersMAD(rets, threshold)
+	if filter == FilterRemove {
+		rets = RemoveOutliers(rets, idx)
+	} else {
+		rets = WinsorizeOutliers(rets, idx)
+	}
+	return StdDev(rets, nil, VarianceTwoPass) * math.Sqrt(periodicity), idx
+}
+
+// MovVolatilityRobust returns moving robust volatility of prices x, the MAD
+// of the logarithmic returns over sliding windows of length k selected by
+// opts, an estimate of their standard deviation which a few bad ticks do not
+// inflate, annualized like Volatility. With weights in opts each window uses
+// the WeightedMAD. It also returns a mask of the returns whose distance from
+// the median return of their window exceeds threshold MADs. The output is
+// padded as if Pad was set in opts, so that both slices are aligned with the
+// returns, where return i is between prices i and i+1.
+func MovVolatilityRobust(x []float64, k int, periodicity, threshold float64, opts WindowOpts) ([]float64, []bool) {
+	rets := LogReturns(x)
+	opts.Pad = true
+	stats := MovApplyMulti(rets, k, opts, 2, func(window, weights, dst []float64) {
+		dst[0] = WeightedMedian(window, weights)
+		dst[1] = WeightedMAD(window, weights)
+	})
+	vol := stats[1]
+	outliers := make([]bool, len(rets))
+	for i := 0; i < len(rets); i++ {
+		outliers[i] = math.Abs(rets[i]-stats[0][i]) > threshold*vol[i]
+		vol[i] *= math.Sqrt(periodicity)
+	}
+	return vol, outliers
+}
+
 // Normalize is normalizing a set of scores x using the standard deviation.
 // This normalization is known as Z-scores. With elementary algebraic
 // manipulations, it can be shown that a set of Z-score has a mean equal of
@@ -85,9 +156,16 @@ func Volatility(x []float64, periodicity float64) float64 {
 // free measure which can be used to compare observations measured with
 // different units.
 func Normalize(x, weights []float64) []float64 {
-	zscores := make([]float64, len(x))
-	mean := stat.Mean(x, weights)
-	stdDev := stat.StdDev(x, weights)
+	return NormalizeTo(make([]float64, len(x)), x, weights)
+}
+
+// NormalizeTo is like Normalize, but stores the Z-scores in dst, which is
+// reused when it has enough capacity, and returns it resliced to len(x).
+// dst may be x to normalize the scores in place.
+func NormalizeTo(dst, x, weights []float64) []float64 {
+	mean := Mean(x, weights)
+	stdDev := StdDev(x, weights, VarianceTwoPass)
+	zscores := resize(dst, len(x))
 	for i := 0; i < len(x); i++ {
 		if stdDev != 0.0 {
 			zscores[i] = (x[i] - mean) / stdDev
@@ -108,48 +186,44 @@ func Normalize(x, weights []float64) []float64 {
 //
 // - fullWnd  - discard any window that uses fewer elements than k
 func RollingWindow(x []float64, k int, omitNaNs, trailing, fullWnd bool) [][]float64 {
-
-	var rets [][]float64
-	var v []float64
-
+	v := x
 	if omitNaNs {
 		v = filterNaNs(x)
-	} else {
-		v = x
 	}
 
-	if !fullWnd {
-		for i := 1; i < k; i++ {
-			rets = append(rets, v[:i])
-		}
-	}
-
-	for i := 0; i+k-1 < len(v); i++ {
-		rets = append(rets, v[i:i+k])
+	it := newWindowIter(len(v), len(x), k, trailing, fullWnd)
+	rets := make([][]float64, 0, it.len())
+	for it.next() {
+		rets = append(rets, v[it.start:it.end])
 	}
+	return rets
+}
 
-	if !fullWnd {
-		for i := k - 1; i > 0; i-- {
-			rets = append(rets, v[len(v)-i:])
+// RollingWindowWith splits slice x into sliding windows of length k selected
+// by opts the same way as by MovApply. With Pad set there is one window for
+// each element of x, nil where there is no window. Weights and NaNSkip are
+// ignored, so windows may contain NaN values.
+func RollingWindowWith(x []float64, k int, opts WindowOpts) [][]float64 {
+	v := windowSeries(x, opts)
+	it := windowIterFor(x, v, k, opts)
+	rets := make([][]float64, 0, it.len())
+	for it.next() {
+		if it.pad {
+			rets = append(rets, nil)
+			continue
 		}
+		rets = append(rets, v[it.start:it.end])
 	}
+	return rets
+}
 
-	if len(x) >= len(rets) {
-		return rets
-	}
-
-	if !trailing {
-		diff := len(rets) - len(v)
-		var trim int
-		if math.Mod(float64(diff), 2.) == 0 {
-			trim = diff / 2
-		} else {
-			trim = (diff - 1) / 2
-		}
-		return rets[trim : len(x)+trim]
+// resize returns dst resliced to n elements, or a new slice if dst does not
+// have enough capacity.
+func resize(dst []float64, n int) []float64 {
+	if cap(dst) < n {
+		return make([]float64, n)
 	}
-
-	return rets[:len(x)]
+	return dst[:n]
 }
 
 func filterNaNs(x []float64) []float64 {
@@ -65,6 +65,19 @@ func TestRollingWindow(t *testing.T) {
 	compareArrays([]float64{4., 5.}, rolling[4], t)
 }
 
+func TestRollingWindowWith_Pad(t *testing.T) {
+	x := []float64{1., 2., 3., 4., 5.}
+	rolling := RollingWindowWith(x, 3, WindowOpts{Align: AlignRight, Pad: true, FullWindow: true})
+	if got, want := len(rolling), len(x); got != want {
+		t.Fatalf("Expected number of elements=%d, got=%d", want, got)
+	}
+	if rolling[0] != nil || rolling[1] != nil {
+		t.Errorf("Expected nil padding windows, got=%v", rolling[:2])
+	}
+	compareArrays([]float64{1., 2., 3.}, rolling[2], t)
+	compareArrays([]float64{3., 4., 5.}, rolling[4], t)
+}
+
 func TestRollingWindow_EvenWindow(t *testing.T) {
 	x := []float64{1., 2., 3., 4., 5.}
 	rolling := RollingWindow(x, 2, false, false, false)
@@ -182,6 +195,43 @@ func TestVolatility(t *testing.T) {
 	}
 }
 
+func TestVolatilityRobust(t *testing.T) {
+	prices := []float64{42.35834, 40.703716, 42.202611, 42.338873, 41.47263,
+		42.718463, 41.920351, 42.13448, 42.319407, 41.891153,
+		4.280606, 43.117518, 43.068854, 42.319407, 42.932591,
+		42.728198, 42.698996, 42.737929, 42.767127, 42.13448,
+		42.280473, 43.078585}
+	if got := Volatility(prices, 252.); got < 10 {
+		t.Fatalf("Expected bad tick to dominate volatility, got=%f", got)
+	}
+	vol, idx := VolatilityRobust(prices, 252., 5., FilterRemove)
+	compareIndices([]int{9, 10}, idx, t)
+	if got, want := vol, 0.2857; !floatEquals(got, want) {
+		t.Errorf("Expected volatility=%f, got=%f", want, got)
+	}
+	vol, _ = VolatilityRobust(prices, 252., 5., FilterWinsorize)
+	if got, want := vol, 0.3315; !floatEquals(got, want) {
+		t.Errorf("Expected volatility=%f, got=%f", want, got)
+	}
+}
+
+func TestMovVolatilityRobust(t *testing.T) {
+	prices := []float64{100., 101., 99., 100.5, 100., 102., 101., 10., 101.5, 100., 101.}
+	vol, outliers := MovVolatilityRobust(prices, 5, 252., 5., WindowOpts{Trailing: true, FullWindow: true})
+	if len(vol) != 10 || len(outliers) != 10 {
+		t.Fatalf("Expected 10 values, got=%d, %d", len(vol), len(outliers))
+	}
+	compareArrays([]float64{math.NaN(), math.NaN(), math.NaN(), math.NaN()}, vol[:4], t)
+	// the MAD of the returns is barely inflated by the bad tick, which
+	// turns the annualized standard deviation into 26
+	compareArrays([]float64{0.2319, 0.3533, 0.4713, 0.5835, 0.6979, 0.4661}, vol[4:], t)
+	for i := 0; i < len(outliers); i++ {
+		if want := i == 6 || i == 7; outliers[i] != want {
+			t.Errorf("Expected outlier at %d=%v, got=%v", i, want, outliers[i])
+		}
+	}
+}
+
 func TestNormalize(t *testing.T) {
 	scores := []float64{35., 36., 46., 68., 70.}
 	zscores := Normalize(scores, nil)
@@ -0,0 +1,117 @@
+package gostat
+
+import (
+	"github.com/gonum/stat/distuv"
+	"math"
+)
+
+// SignRuns returns the runs of consecutive positive values and the runs of
+// consecutive negative values of x, such as the winning and losing streaks
+// of a series of returns. Zero and NaN values end a run without starting
+// one.
+func SignRuns(x []float64) (positive, negative []Run) {
+	start := 0
+	for i := 1; i <= len(x); i++ {
+		s := sign(x[start])
+		if i < len(x) && sign(x[i]) == s && s != 0 {
+			continue
+		}
+		switch s {
+		case 1:
+			positive = append(positive, Run{start, i})
+		case -1:
+			negative = append(negative, Run{start, i})
+		}
+		start = i
+	}
+	return positive, negative
+}
+
+// SignChanges returns the number of times the sign of x changes between
+// consecutive nonzero values, skipping zero and NaN values.
+func SignChanges(x []float64) int {
+	var changes int
+	var last float64
+	for i := 0; i < len(x); i++ {
+		s := sign(x[i])
+		if s == 0 {
+			continue
+		}
+		if last != 0 && s != last {
+			changes++
+		}
+		last = s
+	}
+	return changes
+}
+
+// LongestRun returns the first of the longest runs, or an empty run if there
+// are none.
+func LongestRun(runs []Run) Run {
+	var longest Run
+	for _, r := range runs {
+		if r.Len() > longest.Len() {
+			longest = r
+		}
+	}
+	return longest
+}
+
+// RunLengthCounts returns the distribution of the lengths of runs, where the
+// k-th value is the number of runs of length k.
+func RunLengthCounts(runs []Run) []int {
+	var counts []int
+	for _, r := range runs {
+		for len(counts) <= r.Len() {
+			counts = append(counts, 0)
+		}
+		counts[r.Len()]++
+	}
+	return counts
+}
+
+// RunsTest returns the statistic and the two-sided p-value of the Wald
+// Wolfowitz runs test of the randomness of the signs of x, which compares
+// the number of runs of positive and negative values with its expected
+// value when the signs are independent. A negative statistic means fewer,
+// longer runs than expected, such as from momentum, and a positive one more
+// frequent reversals. Zero and NaN values are ignored. Subtract the median
+// from x first to test the values above and below it. Both values are NaN
+// when there are no positive or no negative values.
+func RunsTest(x []float64) (z, pValue float64) {
+	var pos, neg, runs float64
+	var last float64
+	for i := 0; i < len(x); i++ {
+		s := sign(x[i])
+		switch s {
+		case 0:
+			continue
+		case 1:
+			pos++
+		default:
+			neg++
+		}
+		if s != last {
+			runs++
+		}
+		last = s
+	}
+	if pos == 0 || neg == 0 {
+		return math.NaN(), math.NaN()
+	}
+	n := pos + neg
+	mean := 2*pos*neg/n + 1
+	variance := 2 * pos * neg * (2*pos*neg - n) / (n * n * (n - 1))
+	z = (runs - mean) / math.Sqrt(variance)
+	return z, 2 * distuv.UnitNormal.CDF(-math.Abs(z))
+}
+
+func sign(x float64) float64 {
+	switch {
+	case x > 0:
+		return 1
+	case x < 0:
+		return -1
+	}
+	return 0
+}
@@ -0,0 +1,49 @@
+package gostat
+
+import (
+	"math"
+	"testing"
+)
+
+func TestSignRuns(t *testing.T) {
+	x := []float64{1., 2., -1., 0., 3., 4., 5., -2., -3., math.NaN(), 1.}
+	pos, neg := SignRuns(x)
+	compareRuns([]Run{{0, 2}, {4, 7}, {10, 11}}, pos, t)
+	compareRuns([]Run{{2, 3}, {7, 9}}, neg, t)
+	if got, want := LongestRun(pos), (Run{4, 7}); got != want {
+		t.Errorf("Expected longest run=%v, got=%v", want, got)
+	}
+	if got := LongestRun(nil); got.Len() != 0 {
+		t.Errorf("Expected empty run, got=%v", got)
+	}
+	compareIndices([]int{0, 1, 1, 1}, RunLengthCounts(pos), t)
+	compareIndices([]int{0, 1, 1}, RunLengthCounts(neg), t)
+	if got, want := SignChanges(x), 4; got != want {
+		t.Errorf("Expected sign changes=%d, got=%d", want, got)
+	}
+}
+
+func TestRunsTest(t *testing.T) {
+	z, p := RunsTest([]float64{1., 2., -1., 0., 3., 4., 5., -2., -3., math.NaN(), 1.})
+	if !floatEquals(z, 0) || !floatEquals(p, 1) {
+		t.Errorf("Expected z=0 p=1, got=%f, %f", z, p)
+	}
+	z, p = RunsTest([]float64{1., -1., 1., -1., 1., -1., 1., -1.})
+	if !floatEquals(z, 2.2913) || !floatEquals(p, 0.0219) {
+		t.Errorf("Expected z=%f p=%f, got=%f, %f", 2.2913, 0.0219, z, p)
+	}
+	if z, _ := RunsTest([]float64{1., 2.}); !math.IsNaN(z) {
+		t.Errorf("Expected z=NaN, got=%f", z)
+	}
+}
+
+func compareRuns(want, got []Run, t *testing.T) {
+	if len(want) != len(got) {
+		t.Fatalf("Expected runs=%v, got=%v", want, got)
+	}
+	for i := 0; i < len(want); i++ {
+		if got[i] != want[i] {
+			t.Errorf("Expected run at %d=%v, got=%v", i, want[i], got[i])
+		}
+	}
+}
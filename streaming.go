@@ -0,0 +1,159 @@
+package gostat
+
+import (
+	"math"
+	"sort"
+)
+
+// P2Quantile is a streaming estimate of a quantile by the P² algorithm of
+// Jain and Chlamtac, which tracks five markers of the distribution of the
+// values added so far in constant memory, without storing the values. The
+// estimate is exact for up to five values.
+type P2Quantile struct {
+	p       float64
+	count   int
+	q       [5]float64 // marker heights
+	pos     [5]float64 // marker positions
+	desired [5]float64 // desired marker positions
+	incr    [5]float64 // increments of the desired positions
+}
+
+// NewP2Quantile returns an empty streaming estimate of the p-quantile, for
+// p in (0, 1).
+func NewP2Quantile(p float64) (*P2Quantile, error) {
+	if !(p > 0 && p < 1) {
+		return nil, ErrInvalidProbability
+	}
+	return &P2Quantile{
+		p:       p,
+		desired: [5]float64{0, 2 * p, 4 * p, 2 + 2*p, 4},
+		incr:    [5]float64{0, p / 2, p, (1 + p) / 2, 1},
+	}, nil
+}
+
+// Add adds a value to the estimate. NaN and infinite values are ignored.
+func (e *P2Quantile) Add(x float64) {
+	if !isRealVal(x) {
+		return
+	}
+	if e.count < 5 {
+		e.q[e.count] = x
+		e.count++
+		if e.count == 5 {
+			sort.Float64s(e.q[:])
+			for i := 0; i < 5; i++ {
+				e.pos[i] = float64(i)
+			}
+		}
+		return
+	}
+	e.count++
+
+	var k int
+	switch {
+	case x < e.q[0]:
+		e.q[0] = x
+	case x >= e.q[4]:
+		e.q[4] = x
+		k = 3
+	default:
+		for k = 0; x >= e.q[k+1]; k++ {
+		}
+	}
+	for i := k + 1; i < 5; i++ {
+		e.pos[i]++
+	}
+	for i := 0; i < 5; i++ {
+		e.desired[i] += e.incr[i]
+	}
+
+	// adjust the heights of the middle markers which are off their desired
+	// positions by one or more
+	for i := 1; i < 4; i++ {
+		d := e.desired[i] - e.pos[i]
+		if (d >= 1 && e.pos[i+1]-e.pos[i] > 1) || (d <= -1 && e.pos[i-1]-e.pos[i] < -1) {
+			s := 1.
+			if d < 0 {
+				s = -1
+			}
+			q := e.parabolic(i, s)
+			if !(e.q[i-1] < q && q < e.q[i+1]) {
+				j := i + int(s)
+				q = e.q[i] + s*(e.q[j]-e.q[i])/(e.pos[j]-e.pos[i])
+			}
+			e.q[i] = q
+			e.pos[i] += s
+		}
+	}
+}
+
+func (e *P2Quantile) parabolic(i int, s float64) float64 {
+	q, n := e.q, e.pos
+	return q[i] + s/(n[i+1]-n[i-1])*((n[i]-n[i-1]+s)*(q[i+1]-q[i])/(n[i+1]-n[i])+
+		(n[i+1]-n[i]-s)*(q[i]-q[i-1])/(n[i]-n[i-1]))
+}
+
+// Count returns the number of values added to the estimate.
+func (e *P2Quantile) Count() int {
+	return e.count
+}
+
+// Value returns the estimated quantile, or NaN if no value was added.
+func (e *P2Quantile) Value() float64 {
+	if e.count <= 5 {
+		v := append([]float64{}, e.q[:e.count]...)
+		sort.Float64s(v)
+		return quantileSorted(v, e.p, QuantileLinear)
+	}
+	return e.q[2]
+}
+
+// StreamingMAD is a streaming estimate of the median and the MAD of an
+// unbounded stream of values in constant memory, for maintaining robust
+// outlier thresholds without storing the stream. The median is estimated by
+// P2Quantile, and the MAD as the P2Quantile median of the absolute
+// deviations of each value from the median estimated when it was added,
+// which approximates the MAD of a stationary stream once the median
+// estimate settles.
+type StreamingMAD struct {
+	median, dev *P2Quantile
+}
+
+// NewStreamingMAD returns an empty streaming estimate of the MAD.
+func NewStreamingMAD() *StreamingMAD {
+	median, _ := NewP2Quantile(0.5)
+	dev, _ := NewP2Quantile(0.5)
+	return &StreamingMAD{median: median, dev: dev}
+}
+
+// Add adds a value to the estimate. NaN and infinite values are ignored.
+func (m *StreamingMAD) Add(x float64) {
+	if !isRealVal(x) {
+		return
+	}
+	m.median.Add(x)
+	m.dev.Add(math.Abs(x - m.median.Value()))
+}
+
+// Count returns the number of values added to the estimate.
+func (m *StreamingMAD) Count() int {
+	return m.median.Count()
+}
+
+// Median returns the estimated median, or NaN if no value was added.
+func (m *StreamingMAD) Median() float64 {
+	return m.median.Value()
+}
+
+// MAD returns the estimated MAD, scaled by 1.4826 like MAD, or NaN if no
+// value was added.
+func (m *StreamingMAD) MAD() float64 {
+	return 1.4826 * m.dev.Value()
+}
+
+// IsOutlier reports whether x is farther from the estimated median than
+// threshold times the estimated MAD, as flagged by OutliersMAD, before x is
+// added to the estimate.
+func (m *StreamingMAD) IsOutlier(x, threshold float64) bool {
+	return math.Abs(x-m.Median()) > threshold*m.MAD()
+}
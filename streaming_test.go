@@ -0,0 +1,77 @@
+package gostat
+
+import (
+	"github.com/gonum/stat/distuv"
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func TestP2Quantile(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, p := range []float64{0.1, 0.5, 0.9} {
+		e, err := NewP2Quantile(p)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		for i := 0; i < 20000; i++ {
+			e.Add(rnd.NormFloat64())
+		}
+		want := distuv.UnitNormal.Quantile(p)
+		if got := e.Value(); math.Abs(got-want) > 0.03 {
+			t.Errorf("Expected %.1f-quantile near %f, got=%f", p, want, got)
+		}
+	}
+}
+
+func TestP2Quantile_FiveValues(t *testing.T) {
+	e, _ := NewP2Quantile(0.9)
+	want := []float64{1., 1.9, 2.8, 3.7, 4.6}
+	for i := 0; i < len(want); i++ {
+		e.Add(float64(i + 1))
+		if got := e.Value(); !floatEquals(got, want[i]) {
+			t.Errorf("Expected 0.9-quantile of %d values=%f, got=%f", i+1, want[i], got)
+		}
+	}
+}
+
+func TestP2Quantile_Small(t *testing.T) {
+	e, _ := NewP2Quantile(0.5)
+	if got := e.Value(); !math.IsNaN(got) {
+		t.Errorf("Expected median=NaN, got=%f", got)
+	}
+	for _, v := range []float64{5., 1., math.NaN(), 3., 2.} {
+		e.Add(v)
+	}
+	if got, want := e.Value(), 2.5; !floatEquals(got, want) || e.Count() != 4 {
+		t.Errorf("Expected median=%f of 4 values, got=%f of %d", want, got, e.Count())
+	}
+	if _, err := NewP2Quantile(1); err != ErrInvalidProbability {
+		t.Errorf("Expected error=%v, got=%v", ErrInvalidProbability, err)
+	}
+}
+
+func TestStreamingMAD(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	m := NewStreamingMAD()
+	x := make([]float64, 20000)
+	for i := 0; i < len(x); i++ {
+		x[i] = 10 + 2*rnd.NormFloat64()
+		if i%100 == 0 {
+			x[i] = 1000
+		}
+		m.Add(x[i])
+	}
+	if got, want := m.Median(), Median(x); math.Abs(got-want) > 0.05 {
+		t.Errorf("Expected median near %f, got=%f", want, got)
+	}
+	if got, want := m.MAD(), MAD(x); math.Abs(got-want) > 0.1 {
+		t.Errorf("Expected MAD near %f, got=%f", want, got)
+	}
+	if !m.IsOutlier(1000, 3.5) || m.IsOutlier(12, 3.5) {
+		t.Errorf("Expected only 1000 to be an outlier")
+	}
+	if m.Count() != len(x) {
+		t.Errorf("Expected count=%d, got=%d", len(x), m.Count())
+	}
+}
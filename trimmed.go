@@ -0,0 +1,64 @@
+package gostat
+
+import (
+	"math"
+	"sort"
+)
+
+// TrimmedMean returns the mean of x after removing the fraction proportion
+// of the lowest and of the highest values, rounded down to a whole number of
+// values at each end. A proportion of 0 gives the mean and one close to 0.5
+// the median. NaN is returned for an empty slice, a slice containing NaN or
+// a proportion outside of [0, 0.5).
+func TrimmedMean(x []float64, proportion float64) float64 {
+	v, g := trimmedSorted(x, proportion)
+	if v == nil {
+		return math.NaN()
+	}
+	return Mean(v[g:len(v)-g], nil)
+}
+
+// WinsorizedMean returns the mean of x after replacing the fraction
+// proportion of the lowest and of the highest values with the nearest value
+// that is kept, rounded down to a whole number of values at each end. NaN is
+// returned for an empty slice, a slice containing NaN or a proportion
+// outside of [0, 0.5).
+func WinsorizedMean(x []float64, proportion float64) float64 {
+	v, g := trimmedSorted(x, proportion)
+	if v == nil {
+		return math.NaN()
+	}
+	sum := Sum(v[g:len(v)-g]) + float64(g)*(v[g]+v[len(v)-1-g])
+	return sum / float64(len(v))
+}
+
+// MovTrimmedMean returns moving trimmed mean, a slice of local k-point
+// TrimmedMean values. Weights are ignored.
+func MovTrimmedMean(x []float64, k int, proportion float64, opts WindowOpts) []float64 {
+	return MovApply(x, k, opts, func(window, _ []float64) float64 {
+		return TrimmedMean(window, proportion)
+	})
+}
+
+// MovWinsorizedMean returns moving winsorized mean, a slice of local k-point
+// WinsorizedMean values. Weights are ignored.
+func MovWinsorizedMean(x []float64, k int, proportion float64, opts WindowOpts) []float64 {
+	return MovApply(x, k, opts, func(window, _ []float64) float64 {
+		return WinsorizedMean(window, proportion)
+	})
+}
+
+// trimmedSorted returns a sorted copy of x and the number of values to trim
+// from each end, or nil when the trimmed mean is undefined.
+func trimmedSorted(x []float64, proportion float64) ([]float64, int) {
+	if len(x) == 0 || !(proportion >= 0 && proportion < 0.5) || hasNaN(x) {
+		return nil, 0
+	}
+	v := append([]float64{}, x...)
+	sort.Float64s(v)
+	g := int(proportion * float64(len(v)))
+	if 2*g >= len(v) {
+		g = (len(v) - 1) / 2
+	}
+	return v, g
+}
@@ -0,0 +1,52 @@
+package gostat
+
+import (
+	"math"
+	"testing"
+)
+
+func TestTrimmedMean(t *testing.T) {
+	x := []float64{1., 2., 3., 4., 5., 6., 7., 8., 9., 100.}
+	if got, want := TrimmedMean(x, 0.1), 5.5; !floatEquals(got, want) {
+		t.Errorf("Expected trimmed mean=%f, got=%f", want, got)
+	}
+	if got, want := TrimmedMean(x, 0.), 14.5; !floatEquals(got, want) {
+		t.Errorf("Expected trimmed mean=%f, got=%f", want, got)
+	}
+	if got, want := TrimmedMean(x, 0.25), 5.5; !floatEquals(got, want) {
+		t.Errorf("Expected trimmed mean=%f, got=%f", want, got)
+	}
+	if got := TrimmedMean(x, 0.5); !math.IsNaN(got) {
+		t.Errorf("Expected trimmed mean=NaN, got=%f", got)
+	}
+	if got := TrimmedMean([]float64{1., math.NaN()}, 0.1); !math.IsNaN(got) {
+		t.Errorf("Expected trimmed mean=NaN, got=%f", got)
+	}
+}
+
+func TestWinsorizedMean(t *testing.T) {
+	x := []float64{1., 2., 3., 4., 5., 6., 7., 8., 9., 100.}
+	if got, want := WinsorizedMean(x, 0.1), 5.5; !floatEquals(got, want) {
+		t.Errorf("Expected winsorized mean=%f, got=%f", want, got)
+	}
+	if got, want := WinsorizedMean([]float64{-50., 1., 2., 3., 4.}, 0.2), 2.; !floatEquals(got, want) {
+		t.Errorf("Expected winsorized mean=%f, got=%f", want, got)
+	}
+	if got := WinsorizedMean(nil, 0.1); !math.IsNaN(got) {
+		t.Errorf("Expected winsorized mean=NaN, got=%f", got)
+	}
+}
+
+func TestMovTrimmedMean(t *testing.T) {
+	x := []float64{1., 2., 50., 3., 4., 5.}
+	m := MovTrimmedMean(x, 3, 0.34, WindowOpts{Trailing: true, FullWindow: true})
+	compareArrays([]float64{2., 3., 4., 4.}, m, t)
+	m = MovTrimmedMean(x, 3, 0., WindowOpts{})
+	compareArrays(MovMean(x, 3, WindowOpts{}), m, t)
+}
+
+func TestMovWinsorizedMean(t *testing.T) {
+	x := []float64{1., 2., 50., 3., 4., 5.}
+	m := MovWinsorizedMean(x, 3, 0.34, WindowOpts{Trailing: true, FullWindow: true})
+	compareArrays([]float64{2., 3., 4., 4.}, m, t)
+}
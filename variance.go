@@ -0,0 +1,139 @@
+package gostat
+
+import (
+	"math"
+)
+
+// VarianceMethod selects the algorithm used to calculate a variance.
+type VarianceMethod int
+
+const (
+	// VarianceTwoPass calculates the mean first and then the sum of squared
+	// deviations from it, corrected by the sum of the deviations to cancel
+	// the rounding error of the mean. It is the most accurate method, and
+	// does not lose precision on data with a large mean relative to its
+	// spread, such as price levels.
+	VarianceTwoPass VarianceMethod = iota
+	// VarianceWelford updates the mean and the sum of squared deviations in
+	// a single pass over the data. It is less accurate than the two-pass
+	// method but can be updated incrementally, which makes moving variances
+	// O(n) instead of O(nk).
+	VarianceWelford
+)
+
+// Variance returns the unbiased weighted sample variance of x calculated
+// with method. Weights may be nil for equal weights, otherwise they are
+// frequency weights and the sum of squared deviations is divided by their
+// sum minus one, the same as by stat.Variance.
+func Variance(x, weights []float64, method VarianceMethod) float64 {
+	if weights != nil && len(weights) != len(x) {
+		panic("gostat: slice length mismatch")
+	}
+	if method == VarianceWelford {
+		return welfordVariance(x, weights)
+	}
+	return twoPassVariance(x, weights)
+}
+
+// StdDev returns the unbiased weighted sample standard deviation of x, the
+// square root of Variance calculated with method.
+func StdDev(x, weights []float64, method VarianceMethod) float64 {
+	return math.Sqrt(Variance(x, weights, method))
+}
+
+// MovVariance returns moving variance, a slice of local k-point sample
+// variances over windows selected the same way as by MovApply. Each
+// variance is calculated with the method in opts, and without weights
+// VarianceWelford updates the variance incrementally in O(n) time.
+func MovVariance(x []float64, k int, opts WindowOpts) []float64 {
+	if opts.Weights == nil && opts.Variance == VarianceWelford {
+		return movAccumulate(x, k, opts, &varAcc{})
+	}
+	return MovApply(x, k, opts, func(window, weights []float64) float64 {
+		return Variance(window, weights, opts.Variance)
+	})
+}
+
+// MovStdDevWith returns moving standard deviation, a slice of the square
+// roots of the local k-point variances returned by MovVariance.
+func MovStdDevWith(x []float64, k int, opts WindowOpts) []float64 {
+	if opts.Weights == nil && opts.Variance == VarianceWelford {
+		return movAccumulate(x, k, opts, &varAcc{std: true})
+	}
+	return MovApply(x, k, opts, func(window, weights []float64) float64 {
+		return StdDev(window, weights, opts.Variance)
+	})
+}
+
+func twoPassVariance(x, weights []float64) float64 {
+	n := float64(len(x))
+	if weights != nil {
+		n = Sum(weights)
+	}
+	mean := Mean(x, weights)
+
+	var ss, comp neumaierSum
+	for i := 0; i < len(x); i++ {
+		w := 1.
+		if weights != nil {
+			w = weights[i]
+		}
+		d := x[i] - mean
+		ss.add(w * d * d)
+		comp.add(w * d)
+	}
+	c := comp.total()
+	return (ss.total() - c*c/n) / (n - 1)
+}
+
+// covariance returns the unbiased weighted sample covariance of x and y,
+// calculated with the compensated two-pass method of VarianceTwoPass. The
+// weights are frequency weights, the same as by stat.Covariance.
+func covariance(x, y, weights []float64) float64 {
+	if len(x) != len(y) || (weights != nil && len(weights) != len(x)) {
+		panic("gostat: slice length mismatch")
+	}
+	n := float64(len(x))
+	if weights != nil {
+		n = Sum(weights)
+	}
+	meanX, meanY := Mean(x, weights), Mean(y, weights)
+
+	var sxy, compX, compY neumaierSum
+	for i := 0; i < len(x); i++ {
+		w := 1.
+		if weights != nil {
+			w = weights[i]
+		}
+		dx, dy := x[i]-meanX, y[i]-meanY
+		sxy.add(w * dx * dy)
+		compX.add(w * dx)
+		compY.add(w * dy)
+	}
+	return (sxy.total() - compX.total()*compY.total()/n) / (n - 1)
+}
+
+// correlation returns the weighted Pearson correlation of x and y, their
+// covariance divided by the product of their standard deviations.
+func correlation(x, y, weights []float64) float64 {
+	return covariance(x, y, weights) / math.Sqrt(Variance(x, weights, VarianceTwoPass)*Variance(y, weights, VarianceTwoPass))
+}
+
+// welfordVariance uses the weighted form of Welford's updates by West.
+func welfordVariance(x, weights []float64) float64 {
+	var n, mean, m2 float64
+	for i := 0; i < len(x); i++ {
+		w := 1.
+		if weights != nil {
+			w = weights[i]
+		}
+		if w == 0 {
+			continue
+		}
+		n += w
+		d := x[i] - mean
+		mean += w / n * d
+		m2 += w * d * (x[i] - mean)
+	}
+	return m2 / (n - 1)
+}
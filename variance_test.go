@@ -0,0 +1,83 @@
+package gostat
+
+import (
+	"github.com/gonum/stat"
+	"math"
+	"testing"
+)
+
+func TestVariance(t *testing.T) {
+	x := []float64{2., 4., 4., 4., 5., 5., 7., 9.}
+	w := []float64{1., 2., 1., 1., 3., 1., 1., 2.}
+	for _, method := range []VarianceMethod{VarianceTwoPass, VarianceWelford} {
+		if got, want := Variance(x, nil, method), stat.Variance(x, nil); !floatEquals(got, want) {
+			t.Errorf("Expected variance=%f, got=%f", want, got)
+		}
+		if got, want := Variance(x, w, method), stat.Variance(x, w); !floatEquals(got, want) {
+			t.Errorf("Expected weighted variance=%f, got=%f", want, got)
+		}
+		if got, want := StdDev(x, nil, method), stat.StdDev(x, nil); !floatEquals(got, want) {
+			t.Errorf("Expected std dev=%f, got=%f", want, got)
+		}
+		if got := Variance([]float64{1.}, nil, method); !math.IsNaN(got) {
+			t.Errorf("Expected variance=NaN, got=%f", got)
+		}
+	}
+}
+
+func TestVariance_LargeMean(t *testing.T) {
+	x := []float64{1e9 + 4, 1e9 + 7, 1e9 + 13, 1e9 + 16}
+	if got, want := Variance(x, nil, VarianceTwoPass), 30.; got != want {
+		t.Errorf("Expected variance=%f, got=%f", want, got)
+	}
+	if got, want := Variance(x, nil, VarianceWelford), 30.; !floatEquals(got, want) {
+		t.Errorf("Expected variance=%f, got=%f", want, got)
+	}
+}
+
+func TestCovariance(t *testing.T) {
+	x := []float64{2., 4., 4., 4., 5., 5., 7., 9.}
+	y := []float64{1., 3., 2., 5., 4., 6., 8., 7.}
+	w := []float64{1., 2., 1., 1., 3., 1., 1., 2.}
+	for _, weights := range [][]float64{nil, w} {
+		if got, want := covariance(x, y, weights), stat.Covariance(x, y, weights); !floatEquals(got, want) {
+			t.Errorf("Expected covariance=%f, got=%f", want, got)
+		}
+		if got, want := correlation(x, y, weights), stat.Correlation(x, y, weights); !floatEquals(got, want) {
+			t.Errorf("Expected correlation=%f, got=%f", want, got)
+		}
+	}
+}
+
+func TestCovariance_LargeMean(t *testing.T) {
+	x := []float64{1e9 + 1, 1e9 + 2, 1e9 + 3, 1e9 + 4}
+	y := []float64{1e9 + 2, 1e9 + 4, 1e9 + 6, 1e9 + 8}
+	if got, want := covariance(x, y, nil), 10./3; got != want {
+		t.Errorf("Expected covariance=%v, got=%v", want, got)
+	}
+	if got, want := correlation(x, y, nil), 1.; !floatEquals(got, want) {
+		t.Errorf("Expected correlation=%f, got=%f", want, got)
+	}
+}
+
+func TestMovVariance(t *testing.T) {
+	x := []float64{4., 8., 6., -1., -2., -3., -1., 3., 4., 5.}
+	opts := WindowOpts{Trailing: true}
+	m := MovVariance(x, 3, opts)
+	compareArrays([]float64{math.NaN(), 8., 4., 22.3333, 19., 1., 1., 9.3333, 7., 1.}, m, t)
+	opts.Variance = VarianceWelford
+	compareArrays(m, MovVariance(x, 3, opts), t)
+}
+
+func TestMovStdDevWith_LargeMean(t *testing.T) {
+	x := make([]float64, 50)
+	for i := 0; i < len(x); i++ {
+		x[i] = 1e9 + float64(i%5)
+	}
+	m := MovStdDevWith(x, 5, WindowOpts{Trailing: true, FullWindow: true})
+	for i := 0; i < len(m); i++ {
+		if got, want := m[i], math.Sqrt(2.5); !floatEquals(got, want) {
+			t.Errorf("Expected std dev at index %d=%f, got=%f", i, want, got)
+		}
+	}
+}
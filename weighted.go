@@ -0,0 +1,101 @@
+package gostat
+
+import (
+	"math"
+	"sort"
+)
+
+// WeightedMedian returns the weighted median of x, the value below and above
+// which lie half of the total weight. When the weights split evenly between
+// two values their average is returned, so that with equal weights the
+// result matches Median. With nil weights it is the same as Median.
+func WeightedMedian(x, weights []float64) float64 {
+	return WeightedQuantile(x, weights, 0.5)
+}
+
+// WeightedMAD returns the weighted median absolute deviation, the weighted
+// median of the absolute deviations from the weighted median multiplied by
+// the constant of 1.4826, see MAD.
+func WeightedMAD(x, weights []float64) float64 {
+	if len(x) == 0 {
+		return math.NaN()
+	}
+	median := WeightedMedian(x, weights)
+	series := make([]float64, len(x))
+	for i := 0; i < len(x); i++ {
+		series[i] = math.Abs(median - x[i])
+	}
+	return 1.4826 * WeightedMedian(series, weights)
+}
+
+// WeightedQuantile returns the weighted p-quantile of x, the smallest value
+// at which the cumulative weight of the values sorted from lowest to highest
+// reaches the fraction p of the total weight. When it reaches the fraction
+// exactly the value is averaged with the next one. With nil weights the
+// median is computed like Median and other quantiles by linear
+// interpolation, see Quantile. Values with zero weight are ignored, and NaN
+// is returned for negative weights, an empty slice or p outside of [0, 1].
+func WeightedQuantile(x, weights []float64, p float64) float64 {
+	if weights == nil {
+		if len(x) > 0 && p == 0.5 {
+			return Median(x)
+		}
+		return Quantile(x, p, QuantileLinear)
+	}
+	if len(weights) != len(x) {
+		panic("gostat: slice length mismatch")
+	}
+	if !(p >= 0 && p <= 1) {
+		return math.NaN()
+	}
+
+	var v, w []float64
+	for i := 0; i < len(x); i++ {
+		if weights[i] < 0 {
+			return math.NaN()
+		}
+		if weights[i] > 0 {
+			v = append(v, x[i])
+			w = append(w, weights[i])
+		}
+	}
+	if len(v) == 0 {
+		return math.NaN()
+	}
+	v, w = sortWeighted(v, w)
+	return weightedQuantileSorted(v, w, Sum(w), p)
+}
+
+// sortWeighted returns copies of values and their weights sorted by value.
+func sortWeighted(values, weights []float64) ([]float64, []float64) {
+	idx := make([]int, len(values))
+	for i := 0; i < len(idx); i++ {
+		idx[i] = i
+	}
+	sort.Sort(byValue{idx, values})
+	v := make([]float64, len(idx))
+	w := make([]float64, len(idx))
+	for k, i := range idx {
+		v[k], w[k] = values[i], weights[i]
+	}
+	return v, w
+}
+
+// weightedQuantileSorted returns the weighted p-quantile of the values v
+// sorted from lowest to highest with positive weights w adding up to total.
+func weightedQuantileSorted(v, w []float64, total, p float64) float64 {
+	target := p * total
+	tol := 1e-12 * total
+	var cum float64
+	for i := 0; i < len(v); i++ {
+		cum += w[i]
+		if cum < target-tol {
+			continue
+		}
+		if math.Abs(cum-target) <= tol && p > 0 && i+1 < len(v) {
+			return 0.5 * (v[i] + v[i+1])
+		}
+		return v[i]
+	}
+	return v[len(v)-1]
+}
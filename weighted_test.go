@@ -0,0 +1,74 @@
+package gostat
+
+import (
+	"math"
+	"testing"
+)
+
+func TestWeightedMedian(t *testing.T) {
+	x := []float64{1., 2., 3., 4.}
+	if got, want := WeightedMedian(x, []float64{1., 1., 1., 1.}), Median(x); !floatEquals(got, want) {
+		t.Errorf("Expected median=%f, got=%f", want, got)
+	}
+	if got, want := WeightedMedian(x, nil), Median(x); !floatEquals(got, want) {
+		t.Errorf("Expected median=%f, got=%f", want, got)
+	}
+	if got, want := WeightedMedian(x, []float64{1., 1., 1., 5.}), 4.; !floatEquals(got, want) {
+		t.Errorf("Expected median=%f, got=%f", want, got)
+	}
+	if got, want := WeightedMedian([]float64{3., 1., 2.}, []float64{0.2, 0.3, 0.5}), 2.; !floatEquals(got, want) {
+		t.Errorf("Expected median=%f, got=%f", want, got)
+	}
+}
+
+func TestWeightedMedian_ZeroWeights(t *testing.T) {
+	x := []float64{1., 2., 3., 100.}
+	if got, want := WeightedMedian(x, []float64{1., 1., 1., 0.}), 2.; !floatEquals(got, want) {
+		t.Errorf("Expected median=%f, got=%f", want, got)
+	}
+	if got := WeightedMedian(x, []float64{0., 0., 0., 0.}); !math.IsNaN(got) {
+		t.Errorf("Expected median=NaN, got=%f", got)
+	}
+}
+
+func TestWeightedQuantile(t *testing.T) {
+	x := []float64{10., 20., 30., 40.}
+	w := []float64{1., 2., 3., 4.}
+	cases := []struct {
+		p, want float64
+	}{
+		{0., 10.}, {0.1, 15.}, {0.25, 20.}, {0.3, 25.}, {0.5, 30.}, {0.6, 35.}, {0.9, 40.}, {1., 40.},
+	}
+	for _, c := range cases {
+		if got := WeightedQuantile(x, w, c.p); !floatEquals(got, c.want) {
+			t.Errorf("Expected quantile p=%f=%f, got=%f", c.p, c.want, got)
+		}
+	}
+	if got := WeightedQuantile(x, w, 1.1); !math.IsNaN(got) {
+		t.Errorf("Expected quantile=NaN, got=%f", got)
+	}
+}
+
+func TestWeightedMAD(t *testing.T) {
+	x := []float64{2., 6., 6., 12., 17., 25., 32.}
+	if got, want := WeightedMAD(x, []float64{1., 1., 1., 1., 1., 1., 1.}), MAD(x); !floatEquals(got, want) {
+		t.Errorf("Expected MAD=%f, got=%f", want, got)
+	}
+	if got := WeightedMAD(nil, nil); !math.IsNaN(got) {
+		t.Errorf("Expected MAD=NaN, got=%f", got)
+	}
+}
+
+func TestMovMedian_Weighted(t *testing.T) {
+	x := []float64{1., 2., 3., 4., 5.}
+	m := MovMedian(x, 3, WindowOpts{Weights: []float64{1., 1., 4.}, Trailing: true})
+	compareArrays([]float64{1., 2., 3., 4., 5.}, m, t)
+	m = MovMedian(x, 3, WindowOpts{Weights: []float64{1., 1., 1.}})
+	compareArrays(MovMedian(x, 3, WindowOpts{}), m, t)
+}
+
+func TestMovMAD_Weighted(t *testing.T) {
+	x := []float64{4., 8., 6., -1., -2., -3., -1., 3., 4., 5.}
+	m := MovMAD(x, 3, WindowOpts{Weights: []float64{1., 1., 1.}})
+	compareArrays(MovMAD(x, 3, WindowOpts{}), m, t)
+}
@@ -0,0 +1,401 @@
+package gostat
+
+import (
+	"math"
+)
+
+// windowIter enumerates the bounds of the sliding windows of length k over a
+// series of n elements, following the endpoint truncation rules described by
+// RollingWindow. Windows are yielded in order as half-open ranges
+// [start, end) and both bounds never decrease from one window to the next.
+//
+// Anchored iterators, used for AlignLeft or padded output, instead yield the
+// window anchored at each element in turn. They set pad for the elements
+// without a window, which are discarded by FullWindow or omitted from the
+// series, and leave the previous bounds unchanged.
+type windowIter struct {
+	n, k       int
+	lead, full int
+	pos, last  int
+	start, end int
+	off        int
+
+	anchored bool
+	shift    int
+	fullWnd  bool
+	idx      []int
+	pad      bool
+}
+
+// newWindowIter returns an iterator over windows of length k for a series of
+// n elements, where size is the number of windows expected by the caller
+// (the length of the original series before any NaN values were omitted).
+func newWindowIter(n, size, k int, trailing, fullWnd bool) *windowIter {
+	it := &windowIter{n: n, k: k}
+	if k < 1 {
+		return it
+	}
+	if !fullWnd {
+		it.lead = k - 1
+	}
+	if n >= k {
+		it.full = n - k + 1
+	}
+
+	total := 2*it.lead + it.full
+	it.last = total
+	if size < total {
+		if trailing {
+			it.last = size
+		} else {
+			trim := (total - n) / 2
+			it.pos = trim
+			it.last = trim + size
+			if it.last > total {
+				it.last = total
+			}
+		}
+	}
+	return it
+}
+
+// WindowIter yields the bounds of sliding windows over a series without
+// materializing them, so that callers can process each window in place
+// without any allocation.
+//
+//	it := NewWindowIter(len(x), k, opts)
+//	for it.Next() {
+//		start, end := it.Bounds()
+//		process(x[start:end])
+//	}
+type WindowIter struct {
+	it *windowIter
+}
+
+// NewWindowIter returns an iterator over the windows of length k selected
+// by opts for a series of n elements, the same windows as used by MovApply.
+// Weights and the NaN handling options are ignored.
+func NewWindowIter(n, k int, opts WindowOpts) *WindowIter {
+	return &WindowIter{it: newWindowIterOpts(n, n, k, opts, nil)}
+}
+
+// Next advances the iterator to the next window and reports whether there
+// was one.
+func (w *WindowIter) Next() bool {
+	return w.it.next()
+}
+
+// Bounds returns the current window as the half-open range [start, end)
+// into the series.
+func (w *WindowIter) Bounds() (start, end int) {
+	return w.it.start, w.it.end
+}
+
+// Offset returns the offset of the current window within a full window of
+// length k, so that opts.Weights[Offset():Offset()+end-start] are the
+// weights of a window truncated at the endpoints.
+func (w *WindowIter) Offset() int {
+	return w.it.off
+}
+
+// Padded reports whether the current element has no window in padded
+// output, in which case Bounds should not be used.
+func (w *WindowIter) Padded() bool {
+	return w.it.pad
+}
+
+// Len returns the number of windows remaining.
+func (w *WindowIter) Len() int {
+	return w.it.len()
+}
+
+// AlignedWindowIter yields the aligned windows of several series of the
+// same length at once, such as the returns of a portfolio and its
+// benchmark, so that each window holds the same observations of every
+// series.
+//
+//	it := NewAlignedWindowIter([][]float64{x, y}, k, opts)
+//	for it.Next() {
+//		w := it.Windows()
+//		process(w[0], w[1], it.Weights())
+//	}
+type AlignedWindowIter struct {
+	it      *windowIter
+	series  [][]float64
+	weights []float64
+	skip    bool
+
+	windows [][]float64
+	w       []float64
+	bufs    [][]float64
+	bufW    []float64
+}
+
+// NewAlignedWindowIter returns an iterator over the windows of length k
+// selected by opts across the aligned series, the same windows as used by
+// MovApply for each of them. The NaN handling options keep the series
+// aligned: OmitNaNs omits the observations where any series is NaN or
+// infinite, and NaNSkip removes them from each window. It panics if the
+// series do not have the same length.
+func NewAlignedWindowIter(series [][]float64, k int, opts WindowOpts) *AlignedWindowIter {
+	n := checkSeries(series)
+	vs, idx := alignedSeries(series, opts)
+	m := n
+	if len(vs) > 0 {
+		m = len(vs[0])
+	}
+	return &AlignedWindowIter{
+		it:      newWindowIterOpts(m, n, k, opts, idx),
+		series:  vs,
+		weights: opts.Weights,
+		skip:    opts.NaNPolicy == NaNSkip,
+		windows: make([][]float64, len(series)),
+		bufs:    make([][]float64, len(series)),
+	}
+}
+
+// Next advances the iterator to the next window and reports whether there
+// was one.
+func (w *AlignedWindowIter) Next() bool {
+	if !w.it.next() {
+		return false
+	}
+	if w.it.pad {
+		for j := 0; j < len(w.windows); j++ {
+			w.windows[j] = nil
+		}
+		w.w = nil
+		return true
+	}
+	start, end := w.it.start, w.it.end
+	w.w = nil
+	if w.weights != nil {
+		w.w = w.weights[w.it.off : w.it.off+end-start]
+	}
+	nan := false
+	for j := 0; j < len(w.series); j++ {
+		w.windows[j] = w.series[j][start:end]
+		nan = nan || (w.skip && hasNaN(w.windows[j]))
+	}
+	if nan {
+		w.skipNaNs()
+	}
+	return true
+}
+
+// skipNaNs removes the observations where any window is NaN from the
+// current windows and weights.
+func (w *AlignedWindowIter) skipNaNs() {
+	for j := 0; j < len(w.bufs); j++ {
+		w.bufs[j] = w.bufs[j][:0]
+	}
+	w.bufW = w.bufW[:0]
+	for i := 0; i < len(w.windows[0]); i++ {
+		nan := false
+		for j := 0; j < len(w.windows); j++ {
+			nan = nan || math.IsNaN(w.windows[j][i])
+		}
+		if nan {
+			continue
+		}
+		for j := 0; j < len(w.windows); j++ {
+			w.bufs[j] = append(w.bufs[j], w.windows[j][i])
+		}
+		if w.w != nil {
+			w.bufW = append(w.bufW, w.w[i])
+		}
+	}
+	copy(w.windows, w.bufs)
+	if w.w != nil {
+		w.w = w.bufW
+	}
+}
+
+// Windows returns the current window of each series, nil when Padded. The
+// windows must not be retained after the next call to Next.
+func (w *AlignedWindowIter) Windows() [][]float64 {
+	return w.windows
+}
+
+// Weights returns the weights of the current windows, nil if opts has no
+// weights.
+func (w *AlignedWindowIter) Weights() []float64 {
+	return w.w
+}
+
+// Padded reports whether the current element has no window in padded
+// output.
+func (w *AlignedWindowIter) Padded() bool {
+	return w.it.pad
+}
+
+// Len returns the number of windows remaining.
+func (w *AlignedWindowIter) Len() int {
+	return w.it.len()
+}
+
+// alignedSeries returns the aligned series to split into windows, with the
+// observations where any value is NaN omitted or the NaN values
+// interpolated as selected by opts. When observations are omitted and opts
+// pads the output, idx maps each observation to its position in the
+// series, or to -1 when omitted.
+func alignedSeries(series [][]float64, opts WindowOpts) (vs [][]float64, idx []int) {
+	vs = make([][]float64, len(series))
+	if !opts.OmitNaNs {
+		for j := 0; j < len(series); j++ {
+			vs[j] = windowSeries(series[j], opts)
+		}
+		return vs, nil
+	}
+	if len(series) == 0 {
+		return vs, nil
+	}
+	if opts.Pad {
+		idx = make([]int, len(series[0]))
+	}
+	m := 0
+	for i := 0; i < len(series[0]); i++ {
+		keep := true
+		for j := 0; j < len(series); j++ {
+			keep = keep && isRealVal(series[j][i])
+		}
+		if !keep {
+			if idx != nil {
+				idx[i] = -1
+			}
+			continue
+		}
+		if idx != nil {
+			idx[i] = m
+		}
+		for j := 0; j < len(series); j++ {
+			vs[j] = append(vs[j], series[j][i])
+		}
+		m++
+	}
+	return vs, idx
+}
+
+// windowIterFor returns an iterator over the windows of length k selected
+// by opts over v, the series derived from x by windowSeries.
+func windowIterFor(x, v []float64, k int, opts WindowOpts) *windowIter {
+	var idx []int
+	if opts.Pad && len(v) != len(x) {
+		idx = make([]int, len(x))
+		j := 0
+		for i := 0; i < len(x); i++ {
+			idx[i] = -1
+			if isRealVal(x[i]) {
+				idx[i] = j
+				j++
+			}
+		}
+	}
+	return newWindowIterOpts(len(v), len(x), k, opts, idx)
+}
+
+// newWindowIterOpts returns an iterator over the windows of length k
+// selected by opts for a series of n elements, where size and idx describe
+// the elements of the original series when some were omitted: idx maps
+// each of the size elements to its position in the series, or to -1 when
+// it was omitted.
+func newWindowIterOpts(n, size, k int, opts WindowOpts, idx []int) *windowIter {
+	align := opts.alignment()
+	if !opts.Pad && align != AlignLeft {
+		return newWindowIter(n, size, k, align == AlignRight, opts.FullWindow)
+	}
+	it := &windowIter{n: n, k: k, anchored: true, fullWnd: opts.FullWindow}
+	switch align {
+	case AlignRight:
+		it.shift = k - 1
+	case AlignCenter:
+		it.shift = k / 2
+	}
+	switch {
+	case opts.Pad:
+		it.idx = idx
+		it.last = n
+		if idx != nil {
+			it.last = size
+		}
+	case k < 1:
+	case opts.FullWindow:
+		it.pos = it.shift
+		it.last = maxInt(n-k+it.shift+1, it.pos)
+	default:
+		it.last = n
+	}
+	return it
+}
+
+// len returns the number of windows remaining.
+func (it *windowIter) len() int {
+	return it.last - it.pos
+}
+
+// next advances the iterator to the next window and reports whether there
+// was one. The window bounds are available in start and end, and off holds
+// the offset of the window within a full window of length k, which is used
+// to select the matching weights for windows truncated at the endpoints.
+func (it *windowIter) next() bool {
+	if it.pos >= it.last {
+		return false
+	}
+	if it.anchored {
+		return it.nextAnchored()
+	}
+	var lo int
+	switch j := it.pos; {
+	case j < it.lead:
+		// leading partial window of i elements
+		i := j + 1
+		lo = i - it.k
+		it.start, it.end = 0, minInt(i, it.n)
+	case j < it.lead+it.full:
+		lo = j - it.lead
+		it.start, it.end = lo, lo+it.k
+	default:
+		// trailing partial window of i elements
+		i := it.k - 1 - (j - it.lead - it.full)
+		lo = it.n - i
+		it.start, it.end = maxInt(lo, 0), it.n
+	}
+	it.off = it.start - lo
+	it.pos++
+	return true
+}
+
+func (it *windowIter) nextAnchored() bool {
+	j := it.pos
+	it.pos++
+	it.pad = false
+	if it.idx != nil {
+		if j = it.idx[j]; j < 0 {
+			it.pad = true
+			return true
+		}
+	}
+	lo := j - it.shift
+	hi := lo + it.k
+	if it.k < 1 || (it.fullWnd && (lo < 0 || hi > it.n)) {
+		it.pad = true
+		return true
+	}
+	it.start, it.end = maxInt(lo, 0), minInt(hi, it.n)
+	it.off = it.start - lo
+	return true
+}
+
+func minInt(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+func maxInt(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
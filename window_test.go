@@ -0,0 +1,116 @@
+package gostat
+
+import (
+	"math"
+	"testing"
+)
+
+func TestWindowIter(t *testing.T) {
+	x := []float64{1., 2., 3., 4., 5.}
+	it := NewWindowIter(len(x), 3, WindowOpts{})
+	if got, want := it.Len(), len(x); got != want {
+		t.Errorf("Expected number of windows=%d, got=%d", want, got)
+	}
+	rolling := RollingWindow(x, 3, false, false, false)
+	var i int
+	for it.Next() {
+		start, end := it.Bounds()
+		compareArrays(rolling[i], x[start:end], t)
+		i++
+	}
+	if i != len(rolling) {
+		t.Errorf("Expected number of windows=%d, got=%d", len(rolling), i)
+	}
+}
+
+func TestWindowIter_Padded(t *testing.T) {
+	it := NewWindowIter(4, 3, WindowOpts{Align: AlignLeft, Pad: true, FullWindow: true})
+	var padded []bool
+	for it.Next() {
+		padded = append(padded, it.Padded())
+	}
+	want := []bool{false, false, true, true}
+	for i := 0; i < len(want); i++ {
+		if padded[i] != want[i] {
+			t.Errorf("Expected padded at index %d=%v, got=%v", i, want[i], padded[i])
+		}
+	}
+}
+
+func TestAlignedWindowIter(t *testing.T) {
+	x := []float64{1., 2., math.NaN(), 4., 5.}
+	y := []float64{10., 20., 30., 40., math.NaN()}
+	it := NewAlignedWindowIter([][]float64{x, y}, 2, WindowOpts{Trailing: true, OmitNaNs: true, Pad: true})
+	if got, want := it.Len(), len(x); got != want {
+		t.Errorf("Expected number of windows=%d, got=%d", want, got)
+	}
+	wantX := [][]float64{{1.}, {1., 2.}, nil, {2., 4.}, nil}
+	wantY := [][]float64{{10.}, {10., 20.}, nil, {20., 40.}, nil}
+	var i int
+	for it.Next() {
+		if got, want := it.Padded(), wantX[i] == nil; got != want {
+			t.Errorf("Expected padded at index %d=%v, got=%v", i, want, got)
+		}
+		if !it.Padded() {
+			compareArrays(wantX[i], it.Windows()[0], t)
+			compareArrays(wantY[i], it.Windows()[1], t)
+		}
+		i++
+	}
+}
+
+func TestAlignedWindowIter_NaNSkip(t *testing.T) {
+	x := []float64{1., math.NaN(), 3., 4.}
+	y := []float64{10., 20., 30., math.NaN()}
+	opts := WindowOpts{Weights: []float64{1., 2., 3.}, FullWindow: true, NaNPolicy: NaNSkip}
+	it := NewAlignedWindowIter([][]float64{x, y}, 3, opts)
+	want := [][][]float64{
+		{{1., 3.}, {10., 30.}, {1., 3.}},
+		{{3.}, {30.}, {2.}},
+	}
+	var i int
+	for it.Next() {
+		compareArrays(want[i][0], it.Windows()[0], t)
+		compareArrays(want[i][1], it.Windows()[1], t)
+		compareArrays(want[i][2], it.Weights(), t)
+		i++
+	}
+	if i != len(want) {
+		t.Errorf("Expected number of windows=%d, got=%d", len(want), i)
+	}
+}
+
+func TestAlignedWindowIter_LengthMismatch(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Errorf("Expected a panic for series of different lengths")
+		}
+	}()
+	NewAlignedWindowIter([][]float64{{1., 2.}, {1.}}, 2, WindowOpts{})
+}
+
+func TestMovStdDevTo(t *testing.T) {
+	x := []float64{4., 8., 6., -1., -2., -3., -1., 3., 4., 5.}
+	dst := make([]float64, 0, len(x))
+	m := MovStdDevTo(dst, x, nil, 3, false, true, false)
+	compareArrays(MovStdDev(x, nil, 3, false, true, false), m, t)
+	if &m[0] != &dst[:1][0] {
+		t.Errorf("Expected dst to be reused")
+	}
+	if allocs := testing.AllocsPerRun(10, func() { MovStdDevTo(dst, x, nil, 3, false, true, false) }); allocs > 1 {
+		t.Errorf("Expected at most one allocation, got=%f", allocs)
+	}
+	w := []float64{1., 2., 1.}
+	compareArrays(MovStdDev(x, w, 3, false, false, true), MovStdDevTo(m, x, w, 3, false, false, true), t)
+}
+
+func TestNormalizeTo(t *testing.T) {
+	x := []float64{2., 4., 4., 4., 5., 5., 7., 9.}
+	want := Normalize(x, nil)
+	got := NormalizeTo(x, x, nil)
+	compareArrays(want, got, t)
+	compareArrays(want, x, t)
+	if got := NormalizeTo(nil, []float64{1., 2.}, nil); len(got) != 2 {
+		t.Errorf("Expected number of elements=2, got=%d", len(got))
+	}
+}